@@ -10,8 +10,9 @@ import (
 )
 
 type InvalidCommandError struct {
-	Name string
-	Err  error
+	Name        string
+	Err         error
+	Suggestions []string // Similar command names for unknown commands.
 }
 
 func (e *InvalidCommandError) Error() string {
@@ -32,6 +33,20 @@ func (e *InvalidCommandError) Is(err error) bool {
 	return ok && pe.Name == e.Name && errors.Is(pe.Err, e.Err)
 }
 
+type AmbiguousCommandError struct {
+	Name       string
+	Candidates []string // Commands which have the Name as a prefix.
+}
+
+func (e *AmbiguousCommandError) Error() string {
+	return fmt.Sprintf("cli: ambiguous command: '%s': %s", e.Name, strings.Join(e.Candidates, ", "))
+}
+
+func (e *AmbiguousCommandError) Is(err error) bool {
+	ae, ok := err.(*AmbiguousCommandError)
+	return ok && ae.Name == e.Name
+}
+
 type ExitCode int
 
 func (e ExitCode) Error() string {
@@ -81,32 +96,26 @@ func (e *CommandError) ExitCode() ExitCode {
 	return e.exitCode
 }
 
-var _ Commander = (*commander)(nil)
+var (
+	_ Commander        = (*commander)(nil)
+	_ CommandSuggester = (*commander)(nil)
+	_ EnvLookuper      = (*commander)(nil)
+	_ Warner           = (*commander)(nil)
+	_ CommandMatcher   = (*commander)(nil)
+	_ ConfigLookuper   = (*commander)(nil)
+	_ FirstArgStopper  = (*commander)(nil)
+)
 
 type commander struct {
 	app *App
 	use func(*Command) (Register, error)
 
 	command *Command
+	chain   []*Command // Subcommands from the root.
 }
 
 func (c *commander) IsCommand(name string) bool {
-	var commands []Command
-	if c.command != nil {
-		commands = c.command.Commands
-	} else if c.app != nil {
-		commands = c.app.Commands
-	}
-
-	for i := range commands {
-		cmd := &commands[i]
-
-		if cmd.Name == name {
-			return true
-		}
-	}
-
-	return false
+	return findCommand(c.commands(), name) != nil
 }
 
 func (c *commander) SetCommand(name string) (Register, error) {
@@ -122,31 +131,36 @@ func (c *commander) SetCommand(name string) (Register, error) {
 	}
 
 	// Find a command.
-	var commands []Command
-	if c.command != nil {
-		commands = c.command.Commands
-	} else if c.app != nil {
-		commands = c.app.Commands
-	}
+	commands := c.commands()
 
-	var found *Command
-	for i := range commands {
-		cmd := &commands[i]
+	found := findCommand(commands, name)
 
-		if cmd.Name == name {
-			found = cmd
-			break
+	if found == nil {
+		return nil, &InvalidCommandError{
+			Name:        name,
+			Err:         ErrUnknown,
+			Suggestions: c.SuggestCommands(name),
 		}
 	}
 
-	if found == nil {
-		return nil, &InvalidCommandError{
-			Name: name,
-			Err:  ErrUnknown,
+	if deprecation := found.Deprecation; deprecation != nil {
+		replacement := deprecation.Replacement
+		if replacement != "" {
+			found = findCommand(commands, replacement)
+
+			if found == nil {
+				return nil, &InvalidCommandError{
+					Name: replacement,
+					Err:  ErrUnknown,
+				}
+			}
 		}
+
+		c.Warn(DeprecationWarning("command", name, replacement, deprecation.Message))
 	}
 
 	c.command = found
+	c.chain = append(c.chain, found)
 
 	register, err := c.use(c.command)
 	if err != nil {
@@ -156,6 +170,131 @@ func (c *commander) SetCommand(name string) (Register, error) {
 	return register, nil
 }
 
+func (c *commander) SuggestCommands(name string) []string {
+	commands := c.commands()
+
+	s := newSuggester(name)
+	for i := range commands {
+		if commands[i].Hidden {
+			continue
+		}
+
+		s.Add(commands[i].Name, commands[i].Name)
+
+		for _, alias := range commands[i].Aliases {
+			s.Add(alias, commands[i].Name)
+		}
+	}
+
+	return s.Suggestions()
+}
+
+func (c *commander) MatchCommands(prefix string) []string {
+	var names []string
+
+	commands := c.commands()
+	for i := range commands {
+		cmd := &commands[i]
+
+		if cmd.Hidden {
+			continue
+		}
+
+		if strings.HasPrefix(cmd.Name, prefix) {
+			names = append(names, cmd.Name)
+			continue
+		}
+
+		for _, alias := range cmd.Aliases {
+			if strings.HasPrefix(alias, prefix) {
+				names = append(names, cmd.Name)
+				break
+			}
+		}
+	}
+
+	return names
+}
+
+// commands returns subcommands of the current command.
+func (c *commander) commands() []Command {
+	if c.command != nil {
+		return c.command.Commands
+	}
+
+	if c.app != nil {
+		if c.app.rootCmd != nil {
+			return c.app.rootCmd.Commands
+		}
+
+		return c.app.Commands
+	}
+
+	return nil
+}
+
+func findCommand(commands []Command, name string) *Command {
+	for i := range commands {
+		cmd := &commands[i]
+
+		if cmd.Name == name {
+			return cmd
+		}
+
+		for _, alias := range cmd.Aliases {
+			if alias == name {
+				return cmd
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *commander) LookupEnv(key string) (string, bool) {
+	if c.app != nil {
+		return c.app.lookupEnv(key)
+	}
+
+	return os.LookupEnv(key)
+}
+
+func (c *commander) LookupConfig(depth int, key string) (ConfigValues, bool) {
+	if c.app == nil || c.app.Config == nil || depth > len(c.chain) {
+		return ConfigValues{}, false
+	}
+
+	// The root section has no name.
+	var section []string
+	if depth > 0 {
+		section = c.chain[depth-1].Path()[1:]
+	}
+
+	values, ok := c.app.Config.Lookup(section, key)
+	if !ok {
+		return ConfigValues{}, false
+	}
+
+	return ConfigValues{
+		Values: values,
+		File:   c.app.Config.File(section, key),
+		Key:    strings.Join(append(append([]string{}, section...), key), "."),
+	}, true
+}
+
+func (c *commander) StopAtFirstArg() bool {
+	return c.command != nil && c.command.StopAtFirstArg
+}
+
+func (c *commander) Warn(msg string) {
+	var w io.Writer = os.Stderr
+	if c.app != nil {
+		w = c.app.stderr()
+	}
+
+	_, _ = fmt.Fprintln(w, msg)
+}
+
 func validCommandName(name string) bool {
 	return validArg(name)
 }
@@ -170,6 +309,8 @@ type App struct {
 	Stdout       io.Writer
 	Stderr       io.Writer
 	Stdin        io.Reader
+	LookupEnv    func(key string) (string, bool)
+	Config       *Config
 	Parser       Parser
 	NewRegister  func() Register
 	Helper       Helper
@@ -179,14 +320,68 @@ type App struct {
 	defaultParser *DefaultParser
 }
 
-func (app *App) RunContext(ctx context.Context) error {
+func (app *App) RunContext(ctx context.Context) (err error) {
+	cmd, err := app.parse(ctx, app.args())
+	if err != nil {
+		return err
+	}
+
+	// Close files after the action. Output files are kept only if the action
+	// has run and succeeded.
+	var succeeded bool
+	defer func() {
+		if cerr := cmd.close(!succeeded); err == nil {
+			err = cerr
+		}
+	}()
+
+	// Find and run command flag.
+	if f := findCommandFlag(cmd); f != nil {
+		if f.Action != nil {
+			if err := f.Action.Setup(cmd); err != nil {
+				return err
+			}
+
+			if err := f.Action.Run(cmd); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// Run action.
+	if cmd.Action != nil {
+		if err := cmd.Action.Run(cmd); err != nil {
+			return err
+		}
+	}
+
+	succeeded = true
+
+	return nil
+}
+
+// parse builds the command chain and parses arguments. It returns the last
+// command of the chain.
+func (app *App) parse(ctx context.Context, arguments []string) (*Command, error) {
 	// Inject context into the app.
 	app.ctx = ctx
 
+	// Build a new command tree, so values of previous parses are not reused.
+	app.rootCmd = nil
+
 	// Build the root command.
 	cmd, err := app.command()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// Load config files before parsing.
+	if app.Config != nil {
+		if err := app.Config.Load(); err != nil {
+			return nil, err
+		}
 	}
 
 	cmder := commander{
@@ -215,47 +410,94 @@ func (app *App) RunContext(ctx context.Context) error {
 		},
 	}
 
-	if err := app.parser().Parse(&cmder, cmd.register, app.args()); err != nil {
-		return err
+	if err := app.parser().Parse(&cmder, cmd.register, arguments); err != nil {
+		return nil, err
 	}
 
-	// Find and run command flag.
-	cmdWithCFS := cmd
-	for cmdWithCFS != nil {
-		for i := range cmdWithCFS.CommandFlags {
-			f := &cmdWithCFS.CommandFlags[i]
-			if !f.value {
-				continue
+	return cmd, nil
+}
+
+// findCommandFlag returns the first set command flag of the command or its
+// parents.
+func findCommandFlag(cmd *Command) *CommandFlag {
+	for ; cmd != nil; cmd = cmd.parent {
+		for i := range cmd.CommandFlags {
+			if f := &cmd.CommandFlags[i]; f.value {
+				return f
 			}
+		}
+	}
 
-			if f.Action != nil {
-				if err := f.Action.Setup(cmd); err != nil {
-					return err
-				}
+	return nil
+}
 
-				if err := f.Action.Run(cmd); err != nil {
-					return err
-				}
+func (app *App) Run() error {
+	return app.RunContext(context.Background())
+}
+
+// ParseResult is the result of App.Parse.
+type ParseResult struct {
+	Command     *Command     // The last command of the chain.
+	Flags       []*Flag      // Set flags of the command chain.
+	Args        []*Arg       // Set args of the command chain.
+	Rest        *RestArgs    // Filled rest args of the command or nil.
+	CommandFlag *CommandFlag // The set command flag or nil.
+	Unknown     []string     // Ignored flags and args of the command chain.
+	Passthrough []string     // Arguments after "--" if the command has passthrough args.
+}
+
+// Parse builds the command chain and parses arguments like RunContext does
+// but it doesn't run actions. It can be used to validate and inspect command
+// lines.
+func (app *App) Parse(ctx context.Context, args []string) (*ParseResult, error) {
+	cmd, err := app.parse(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commands from the root.
+	var chain []*Command
+	for c := cmd; c != nil; c = c.parent {
+		chain = append([]*Command{c}, chain...)
+	}
+
+	res := &ParseResult{
+		Command:     cmd,
+		CommandFlag: findCommandFlag(cmd),
+	}
+
+	for _, c := range chain {
+		flags := c.Flags()
+		for i := range flags {
+			flag := &flags[i]
+
+			// Skip global flags which are set via inherited copies.
+			if !flag.Set() || (c != cmd && flag.Global && inheritedFlag(cmd.register, flag)) {
+				continue
 			}
 
-			return nil
+			res.Flags = append(res.Flags, flag)
 		}
 
-		cmdWithCFS = cmdWithCFS.parent
+		args := c.Args()
+		for i := range args {
+			if args[i].Set() {
+				res.Args = append(res.Args, &args[i])
+			}
+		}
 	}
 
-	// Run action.
-	if cmd.Action != nil {
-		if err := cmd.Action.Run(cmd); err != nil {
-			return err
-		}
+	if rest := cmd.Rest(); rest != nil && rest.Set() {
+		res.Rest = rest
 	}
 
-	return nil
-}
+	res.Unknown = cmd.Unknown()
 
-func (app *App) Run() error {
-	return app.RunContext(context.Background())
+	if pt := cmd.Passthrough(); pt != nil {
+		res.Passthrough = *pt.Values
+	}
+
+	return res, nil
 }
 
 func (app *App) RootCommand(path ...string) (*Command, error) {
@@ -313,6 +555,27 @@ func (app *App) Help(cmd *Command, w io.Writer) error {
 	return (DefaultHelper{}).Help(cmd, w)
 }
 
+// HelpAll writes the help for the command including hidden commands, flags and
+// args. Custom helpers are used as is.
+func (app *App) HelpAll(cmd *Command, w io.Writer) error {
+	switch h := app.Helper.(type) {
+	case nil:
+		return (DefaultHelper{ShowHidden: true}).Help(cmd, w)
+
+	case DefaultHelper:
+		h.ShowHidden = true
+		return h.Help(cmd, w)
+
+	case *DefaultHelper:
+		hc := *h
+		hc.ShowHidden = true
+		return hc.Help(cmd, w)
+
+	default:
+		return h.Help(cmd, w)
+	}
+}
+
 func (app *App) HandleError(err error) {
 	code := app.handleError(err, app.stderr())
 	if code != 0 {
@@ -333,15 +596,35 @@ func (app *App) handleError(err error, w io.Writer) (exitCode ExitCode) {
 
 	ew := easyWriter{w: w}
 
+	writeSuggestions := func(suggestions []string) {
+		switch len(suggestions) {
+		case 0:
+			// Nothing to do.
+
+		case 1:
+			ew.Writef("Did you mean %s?\n", suggestions[0])
+
+		default:
+			ew.WriteString("Did you mean one of these?\n")
+			for _, s := range suggestions {
+				ew.Writef("    %s\n", s)
+			}
+		}
+	}
+
 	// NOTE(SuperPaintman): ParseValueError is not a top level error.
 
 	cmdErr := &CommandError{}
 	invalidCommandErr := &InvalidCommandError{}
+	ambiguousCommandErr := &AmbiguousCommandError{}
 	parseArgErr := &ParseArgError{}
 	parseFlagErr := &ParseFlagError{}
 	flagErr := &FlagError{}
 	argErr := &ArgError{}
 	restArgsErr := &RestArgsError{}
+	constraintErr := &ConstraintError{}
+	responseFileErr := &ResponseFileError{}
+	configErr := &ConfigError{}
 	switch {
 	case errors.As(err, &exitCode):
 		// Nothing to do.
@@ -349,6 +632,10 @@ func (app *App) handleError(err error, w io.Writer) (exitCode ExitCode) {
 	case errors.As(err, &cmdErr):
 		exitCode = cmdErr.ExitCode()
 
+	case errors.As(err, &ambiguousCommandErr):
+		ew.Writef("Ambiguous command: %s\n", ambiguousCommandErr.Name)
+		writeSuggestions(ambiguousCommandErr.Candidates)
+
 	case errors.As(err, &invalidCommandErr):
 		switch {
 		case errors.Is(invalidCommandErr.Err, ErrMissingName):
@@ -357,6 +644,10 @@ func (app *App) handleError(err error, w io.Writer) (exitCode ExitCode) {
 		case errors.Is(invalidCommandErr.Err, ErrInvalidName):
 			ew.Writef("Invalie command name: %s\n", invalidCommandErr.Name)
 
+		case errors.Is(invalidCommandErr.Err, ErrUnknown):
+			ew.Writef("Unknown command: %s\n", invalidCommandErr.Name)
+			writeSuggestions(invalidCommandErr.Suggestions)
+
 		default:
 			ew.WriteString(err.Error())
 			ew.WriteString("\n")
@@ -366,6 +657,7 @@ func (app *App) handleError(err error, w io.Writer) (exitCode ExitCode) {
 		switch {
 		case errors.Is(parseArgErr.Err, ErrUnknown):
 			ew.Writef("Unknown %s argument: %s\n", nthNumber(parseArgErr.Index), parseArgErr.Arg)
+			writeSuggestions(parseArgErr.Suggestions)
 
 		default:
 			ew.WriteString(err.Error())
@@ -379,6 +671,7 @@ func (app *App) handleError(err error, w io.Writer) (exitCode ExitCode) {
 
 		case errors.Is(parseFlagErr.Err, ErrUnknown):
 			ew.Writef("Unknown flag: %s\n", parseFlagErr.Name)
+			writeSuggestions(parseFlagErr.Suggestions)
 
 		default:
 			ew.WriteString(err.Error())
@@ -417,6 +710,11 @@ func (app *App) handleError(err error, w io.Writer) (exitCode ExitCode) {
 			writeFlagName(flagErr.Short, flagErr.Long)
 			ew.WriteString("\n")
 
+		case errors.Is(flagErr.Err, ErrNotBool):
+			ew.WriteString("Unable to register a negatable non-bool flag: ")
+			writeFlagName(flagErr.Short, flagErr.Long)
+			ew.WriteString("\n")
+
 		case errors.Is(flagErr.Err, ErrNotProvided):
 			ew.WriteString("Flag is required: ")
 			writeFlagName(flagErr.Short, flagErr.Long)
@@ -425,10 +723,20 @@ func (app *App) handleError(err error, w io.Writer) (exitCode ExitCode) {
 		case errors.As(flagErr.Err, &parseValueError):
 			ew.WriteString("Invalid ")
 			writeFlagName(flagErr.Short, flagErr.Long)
-			ew.WriteString(" flag value: ")
+			if flagErr.Env != "" {
+				ew.Writef(" flag value from $%s: ", flagErr.Env)
+			} else if flagErr.ConfigFile != "" {
+				ew.Writef(" flag value from %s in %s: ", flagErr.ConfigKey, flagErr.ConfigFile)
+			} else {
+				ew.WriteString(" flag value: ")
+			}
 			ew.WriteString(parseValueError.Error())
 			ew.WriteString("\n")
 
+			if choiceErr := (&ChoiceError{}); errors.As(parseValueError, &choiceErr) {
+				writeSuggestions(choiceErr.Suggestions)
+			}
+
 		default:
 			ew.WriteString(err.Error())
 			ew.WriteString("\n")
@@ -472,6 +780,43 @@ func (app *App) handleError(err error, w io.Writer) (exitCode ExitCode) {
 			ew.WriteString(parseValueError.Error())
 			ew.WriteString("\n")
 
+			if choiceErr := (&ChoiceError{}); errors.As(parseValueError, &choiceErr) {
+				writeSuggestions(choiceErr.Suggestions)
+			}
+
+		default:
+			ew.WriteString(err.Error())
+			ew.WriteString("\n")
+		}
+
+	case errors.As(err, &responseFileErr):
+		ew.Writef("Unable to read the response file %s: %s\n", responseFileErr.Location(), responseFileErr.Err)
+
+	case errors.As(err, &configErr):
+		ew.Writef("Unable to read the config file %s: %s\n", configErr.Location(), configErr.Err)
+
+	case errors.As(err, &constraintErr):
+		names := constraintErr.Flags
+		joinNames := func(names []string) string {
+			if len(names) < 2 {
+				return strings.Join(names, "")
+			}
+
+			return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+		}
+
+		switch {
+		case errors.Is(constraintErr.Err, ErrMutuallyExclusive):
+			ew.Writef("Flags %s cannot be used together\n", joinNames(names))
+
+		case errors.Is(constraintErr.Err, ErrRequiredTogether):
+			ew.Writef("Flags %s must be used together\n", joinNames(names))
+
+		case errors.Is(constraintErr.Err, ErrRequiredUnless) && len(names) > 1:
+			ew.Writef("Flag %s is required unless %s is set\n",
+				names[0], strings.Join(names[1:], " or "),
+			)
+
 		default:
 			ew.WriteString(err.Error())
 			ew.WriteString("\n")
@@ -517,8 +862,8 @@ func (app *App) command() (*Command, error) {
 			Name:         app.Name,
 			Usage:        app.Usage,
 			Action:       app.Action,
-			CommandFlags: app.CommandFlags,
-			Commands:     app.Commands,
+			CommandFlags: copyCommandFlags(app.CommandFlags),
+			Commands:     copyCommands(app.Commands),
 		}
 
 		path := []string{cmd.Name}
@@ -534,6 +879,51 @@ func (app *App) command() (*Command, error) {
 	return app.rootCmd, nil
 }
 
+// copyCommands returns copies of commands without the state of parsing.
+func copyCommands(commands []Command) []Command {
+	if commands == nil {
+		return nil
+	}
+
+	res := make([]Command, len(commands))
+	for i := range commands {
+		c := commands[i]
+
+		// Reset the state of parsing.
+		c.ctx = nil
+		c.app = nil
+		c.parent = nil
+		c.register = nil
+		c.path = nil
+		c.closers = nil
+		c.err = nil
+		c.initilized = false
+		c.setuped = false
+
+		c.CommandFlags = copyCommandFlags(c.CommandFlags)
+		c.Commands = copyCommands(c.Commands)
+
+		res[i] = c
+	}
+
+	return res
+}
+
+// copyCommandFlags returns copies of command flags without values.
+func copyCommandFlags(flags []CommandFlag) []CommandFlag {
+	if flags == nil {
+		return nil
+	}
+
+	res := make([]CommandFlag, len(flags))
+	for i := range flags {
+		res[i] = flags[i]
+		res[i].value = false
+	}
+
+	return res
+}
+
 func (app *App) args() []string {
 	if app.Args != nil {
 		return app.Args
@@ -566,6 +956,14 @@ func (app *App) stdin() io.Reader {
 	return os.Stdin
 }
 
+func (app *App) lookupEnv(key string) (string, bool) {
+	if app.LookupEnv != nil {
+		return app.LookupEnv(key)
+	}
+
+	return os.LookupEnv(key)
+}
+
 func (app *App) parser() Parser {
 	if app.Parser != nil {
 		return app.Parser
@@ -592,16 +990,25 @@ var _ Register = (*Command)(nil)
 
 type Command struct {
 	Name         string
+	Aliases      []string
 	Usage        Usager
 	Action       Action
 	CommandFlags []CommandFlag
 	Commands     []Command
+	Hidden       bool // Hide the command from the help and completion scripts.
+	Deprecation  *Deprecation
+
+	// StopAtFirstArg treats all arguments after the first positional argument
+	// of the command as args (e.g. "app exec ls -la").
+	StopAtFirstArg bool
 
 	ctx        context.Context
 	app        *App
 	parent     *Command
 	register   Register
 	path       []string
+	closers    []io.Closer // Closed after the action is run (e.g. files).
+	err        error       // Registration error unsupported by the register.
 	initilized bool
 	setuped    bool
 }
@@ -644,7 +1051,13 @@ func (c *Command) Rest() *RestArgs { return c.register.Rest() }
 
 func (c *Command) Flags() []Flag { return c.register.Flags() }
 
-func (c *Command) Err() error { return c.register.Err() }
+func (c *Command) Err() error {
+	if err := c.register.Err(); err != nil {
+		return err
+	}
+
+	return c.err
+}
 
 func (c *Command) Stdout() io.Writer { return c.app.stdout() }
 
@@ -689,6 +1102,50 @@ func (c *Command) WrapError(err error) error {
 	}
 }
 
+func visibleCommands(commands []Command) []*Command {
+	res := make([]*Command, 0, len(commands))
+	for i := range commands {
+		if !commands[i].Hidden {
+			res = append(res, &commands[i])
+		}
+	}
+
+	return res
+}
+
+// onClose registers the closer to be closed after the action is run.
+func (c *Command) onClose(closer io.Closer) {
+	c.closers = append(c.closers, closer)
+}
+
+// close closes closers of the command and its parents. If the action failed,
+// closers which support it (e.g. atomic output files) are discarded.
+func (c *Command) close(failed bool) error {
+	var firstErr error
+	for cmd := c; cmd != nil; cmd = cmd.parent {
+		for _, closer := range cmd.closers {
+			var err error
+			if d, ok := closer.(discarder); ok && failed {
+				err = d.Discard()
+			} else {
+				err = closer.Close()
+			}
+
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+
+		cmd.closers = nil
+	}
+
+	return firstErr
+}
+
+type discarder interface {
+	Discard() error
+}
+
 func (c *Command) init(ctx context.Context, app *App, parent *Command, register Register, path []string) {
 	if c.initilized {
 		return
@@ -714,6 +1171,19 @@ func (c *Command) setup() error {
 		}
 	}
 
+	// Errors which the register can't record.
+	if c.err != nil {
+		return c.err
+	}
+
+	// Inherit global flags of the parent. The parent already has global flags
+	// of its parents.
+	if c.parent != nil {
+		if err := c.inheritFlags(c.parent.Flags()); err != nil {
+			return err
+		}
+	}
+
 	// Add command flags.
 	var parents []*Command
 
@@ -751,9 +1221,12 @@ func (c *Command) setup() error {
 	}
 
 	// Save default values.
+	// Inherited flags already have defaults of the parent.
 	flags := c.Flags()
 	for i := range flags {
-		flags[i].SaveDefault()
+		if !flags[i].inherited {
+			flags[i].SaveDefault()
+		}
 	}
 
 	args := c.Args()
@@ -770,6 +1243,48 @@ func (c *Command) setup() error {
 	return nil
 }
 
+func (c *Command) inheritFlags(flags []Flag) error {
+	for i := range flags {
+		flag := flags[i]
+
+		if !flag.Global {
+			continue
+		}
+
+		// Flags of the command shadow global flags of parents.
+		if c.hasFlag(&flag) {
+			continue
+		}
+
+		// The copy shares the Value with the parent's flag and keeps its "set"
+		// state, so flags parsed before the subcommand name are still counted
+		// as set.
+		flag.inherited = true
+
+		if err := c.RegisterFlag(flag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Command) hasFlag(flag *Flag) bool {
+	for _, name := range append(flag.LongNames(), flag.NegativeLongs()...) {
+		if _, ok := c.LongFlag(name); ok {
+			return true
+		}
+	}
+
+	for _, name := range flag.ShortNames() {
+		if _, ok := c.ShortFlag(name); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 type Action interface {
 	Setup(cmd *Command) error
 	Run(cmd *Command) error
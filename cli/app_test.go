@@ -1,8 +1,10 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -378,3 +380,977 @@ func TestCommanderUnknownCommand(t *testing.T) {
 		t.Fatalf("SetCommand(): got error = %q, want error = %q", got, want)
 	}
 }
+
+func TestCommanderUnknownCommand_suggestions(t *testing.T) {
+	cmdr := commander{
+		command: &Command{
+			Name: "test",
+			Commands: []Command{
+				{Name: "clone"},
+				{Name: "close"},
+				{Name: "commit"},
+			},
+		},
+		use: func(*Command) (Register, error) { return &DefaultRegister{}, nil },
+	}
+
+	_, err := cmdr.SetCommand("clnoe")
+
+	var got *InvalidCommandError
+	if !errors.As(err, &got) {
+		t.Fatalf("SetCommand(): got error = %q, want InvalidCommandError", err)
+	}
+
+	want := []string{"clone", "close"}
+	if !reflect.DeepEqual(got.Suggestions, want) {
+		t.Errorf("SetCommand(): suggestions: got = %#v, want = %#v", got.Suggestions, want)
+	}
+}
+
+func TestApp_handleError_suggestions(t *testing.T) {
+	tt := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "flag",
+			args: []string{"clone", "--jbos", "4"},
+			want: "Unknown flag: --jbos\nDid you mean --jobs?\n",
+		},
+		{
+			name: "command",
+			args: []string{"clnoe"},
+			want: "Unknown 1st argument: clnoe\nDid you mean one of these?\n    clone\n    close\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			app := App{
+				Name: "test",
+				Args: tc.args,
+				Commands: []Command{
+					{
+						Name: "clone",
+						Action: ActionFunc(func(cmd *Command) ActionRunner {
+							_ = Int(cmd, "jobs", WithShort("j"))
+
+							return func(cmd *Command) error { return nil }
+						}),
+					},
+					{Name: "close"},
+				},
+			}
+
+			var buf strings.Builder
+			code := app.handleError(app.Run(), &buf)
+			if code != 1 {
+				t.Errorf("handleError(): exit code: got = %d, want = %d", code, 1)
+			}
+
+			assertStringsDiff(t, buf.String(), tc.want)
+		})
+	}
+}
+
+func TestApp_Run_env(t *testing.T) {
+	env := map[string]string{
+		"TEST_JOBS":    "4",
+		"TEST_VERBOSE": "true",
+		"TEST_NAME":    "env",
+		"TEST_EMPTY":   "",
+	}
+
+	tt := []struct {
+		name        string
+		args        []string
+		wantJobs    int
+		wantVerbose bool
+		wantName    string
+		wantEmpty   string
+	}{
+		{
+			name:        "from env",
+			wantJobs:    4,
+			wantVerbose: true,
+			wantName:    "env",
+			wantEmpty:   "default",
+		},
+		{
+			name:        "cli overrides env",
+			args:        []string{"--jobs", "8", "--name=cli"},
+			wantJobs:    8,
+			wantVerbose: true,
+			wantName:    "cli",
+			wantEmpty:   "default",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				jobs    int
+				verbose bool
+				name    string
+				empty   = "default"
+			)
+
+			app := App{
+				Name: "test",
+				Args: append([]string{}, tc.args...),
+				LookupEnv: func(key string) (string, bool) {
+					v, ok := env[key]
+					return v, ok
+				},
+				Action: ActionFunc(func(cmd *Command) ActionRunner {
+					_ = IntVar(cmd, &jobs, "jobs", WithEnv("TEST_JOBS"), Required)
+					_ = BoolVar(cmd, &verbose, "verbose", WithEnv("TEST_VERBOSE"))
+					_ = StringVar(cmd, &name, "name", WithEnv("TEST_NAME"))
+					_ = StringVar(cmd, &empty, "empty", WithEnv("TEST_EMPTY"))
+
+					return func(cmd *Command) error { return nil }
+				}),
+			}
+
+			if err := app.Run(); err != nil {
+				t.Fatalf("Run(): failed to run: %s", err)
+			}
+
+			if jobs != tc.wantJobs {
+				t.Errorf("Run(): jobs: got = %v, want = %v", jobs, tc.wantJobs)
+			}
+
+			if verbose != tc.wantVerbose {
+				t.Errorf("Run(): verbose: got = %v, want = %v", verbose, tc.wantVerbose)
+			}
+
+			if name != tc.wantName {
+				t.Errorf("Run(): name: got = %q, want = %q", name, tc.wantName)
+			}
+
+			if empty != tc.wantEmpty {
+				t.Errorf("Run(): empty: got = %q, want = %q", empty, tc.wantEmpty)
+			}
+		})
+	}
+}
+
+func TestApp_Run_env_invalid_value(t *testing.T) {
+	app := App{
+		Name: "test",
+		Args: []string{},
+		LookupEnv: func(key string) (string, bool) {
+			return "four", key == "TEST_JOBS"
+		},
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Int(cmd, "jobs", WithEnv("TEST_JOBS"))
+
+			return func(cmd *Command) error { return nil }
+		}),
+	}
+
+	err := app.Run()
+	want := &FlagError{Long: "jobs", Err: &ParseValueError{Type: "int", Err: ErrSyntax}}
+	if !errors.Is(err, want) {
+		t.Fatalf("Run(): got error = %q, want error = %q", err, want)
+	}
+
+	var buf strings.Builder
+	_ = app.handleError(err, &buf)
+
+	assertStringsDiff(t, buf.String(), "Invalid --jobs flag value from $TEST_JOBS: parse int error: invalid syntax\n")
+}
+
+func TestApp_Run_deprecated(t *testing.T) {
+	tt := []struct {
+		name        string
+		args        []string
+		env         map[string]string
+		wantJobs    int
+		wantThreads int
+		wantDir     string
+		wantCommand string
+		wantStderr  string
+	}{
+		{
+			name:        "not used",
+			args:        []string{"run", "--jobs", "2"},
+			wantJobs:    2,
+			wantCommand: "run",
+		},
+		{
+			name:        "flag forwarded once",
+			args:        []string{"run", "--threads", "4", "-t", "8"},
+			wantJobs:    8,
+			wantThreads: 8,
+			wantCommand: "run",
+			wantStderr:  "Warning: flag --threads is deprecated, use --jobs instead\n",
+		},
+		{
+			name:        "flag from env forwarded",
+			args:        []string{"run"},
+			env:         map[string]string{"TEST_THREADS": "4"},
+			wantJobs:    4,
+			wantThreads: 4,
+			wantCommand: "run",
+			wantStderr:  "Warning: flag --threads is deprecated, use --jobs instead\n",
+		},
+		{
+			name:        "flag from env does not override replacement",
+			args:        []string{"run", "--jobs", "2"},
+			env:         map[string]string{"TEST_THREADS": "4"},
+			wantJobs:    2,
+			wantThreads: 4,
+			wantCommand: "run",
+			wantStderr:  "Warning: flag --threads is deprecated, use --jobs instead\n",
+		},
+		{
+			name:        "arg forwarded",
+			args:        []string{"run", "src"},
+			wantDir:     "src",
+			wantCommand: "run",
+			wantStderr:  "Warning: argument path is deprecated, use --dir instead: pass a directory explicitly\n",
+		},
+		{
+			name:        "command forwarded",
+			args:        []string{"start", "--jobs", "2"},
+			wantJobs:    2,
+			wantCommand: "run",
+			wantStderr:  "Warning: command start is deprecated, use run instead\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				jobs    int
+				threads int
+				dir     string
+				command string
+				stderr  strings.Builder
+			)
+
+			action := ActionFunc(func(cmd *Command) ActionRunner {
+				_ = IntVar(cmd, &jobs, "jobs", WithShort("j"))
+				_ = IntVar(cmd, &threads, "threads",
+					WithShort("t"),
+					WithEnv("TEST_THREADS"),
+					Deprecation{Replacement: "jobs"},
+				)
+				_ = StringVar(cmd, &dir, "dir")
+				_ = StringArg(cmd, "path",
+					Optional,
+					Deprecation{Message: "pass a directory explicitly", Replacement: "dir"},
+				)
+
+				return func(cmd *Command) error {
+					command = cmd.Name
+					return nil
+				}
+			})
+
+			app := App{
+				Name:   "test",
+				Args:   append([]string{}, tc.args...),
+				Stderr: &stderr,
+				LookupEnv: func(key string) (string, bool) {
+					v, ok := tc.env[key]
+					return v, ok
+				},
+				Commands: []Command{
+					{
+						Name:   "run",
+						Action: action,
+					},
+					{
+						Name:        "start",
+						Action:      action,
+						Deprecation: &Deprecation{Replacement: "run"},
+					},
+				},
+			}
+
+			if err := app.Run(); err != nil {
+				t.Fatalf("Run(): failed to run: %s", err)
+			}
+
+			if jobs != tc.wantJobs {
+				t.Errorf("Run(): jobs: got = %v, want = %v", jobs, tc.wantJobs)
+			}
+
+			if threads != tc.wantThreads {
+				t.Errorf("Run(): threads: got = %v, want = %v", threads, tc.wantThreads)
+			}
+
+			if dir != tc.wantDir {
+				t.Errorf("Run(): dir: got = %q, want = %q", dir, tc.wantDir)
+			}
+
+			if command != tc.wantCommand {
+				t.Errorf("Run(): command: got = %q, want = %q", command, tc.wantCommand)
+			}
+
+			assertStringsDiff(t, stderr.String(), tc.wantStderr)
+		})
+	}
+}
+
+func TestApp_Run_global(t *testing.T) {
+	env := map[string]string{
+		"TEST_CONFIG": "env.json",
+	}
+
+	tt := []struct {
+		name        string
+		args        []string
+		wantVerbose bool
+		wantConfig  string
+		wantLevel   int
+		wantErr     error
+	}{
+		{
+			name:        "before subcommand",
+			args:        []string{"--verbose", "-c", "app.json", "sub", "leaf"},
+			wantVerbose: true,
+			wantConfig:  "app.json",
+		},
+		{
+			name:        "after subcommand",
+			args:        []string{"sub", "--verbose", "leaf", "-c", "app.json"},
+			wantVerbose: true,
+			wantConfig:  "app.json",
+		},
+		{
+			name:       "from env",
+			args:       []string{"sub", "leaf"},
+			wantConfig: "env.json",
+		},
+		{
+			name:      "not global",
+			args:      []string{"sub", "--level", "2"},
+			wantErr:   &ParseFlagError{Name: "--level", Err: ErrUnknown},
+			wantLevel: 0,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				verbose bool
+				config  string
+				level   int
+			)
+
+			noop := ActionFunc(func(cmd *Command) ActionRunner {
+				return func(cmd *Command) error { return nil }
+			})
+
+			app := App{
+				Name: "test",
+				Args: append([]string{}, tc.args...),
+				LookupEnv: func(key string) (string, bool) {
+					v, ok := env[key]
+					return v, ok
+				},
+				Action: ActionFunc(func(cmd *Command) ActionRunner {
+					_ = BoolVar(cmd, &verbose, "verbose", Global)
+					_ = StringVar(cmd, &config, "config",
+						WithShort("c"),
+						WithEnv("TEST_CONFIG"),
+						Required,
+						Global,
+					)
+					_ = IntVar(cmd, &level, "level")
+
+					return func(cmd *Command) error { return nil }
+				}),
+				Commands: []Command{
+					{
+						Name:   "sub",
+						Action: noop,
+						Commands: []Command{
+							{
+								Name:   "leaf",
+								Action: noop,
+							},
+						},
+					},
+				},
+			}
+
+			err := app.Run()
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("Run(): got error = %q, want error = %q", err, tc.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Run(): failed to run: %s", err)
+			}
+
+			if verbose != tc.wantVerbose {
+				t.Errorf("Run(): verbose: got = %v, want = %v", verbose, tc.wantVerbose)
+			}
+
+			if config != tc.wantConfig {
+				t.Errorf("Run(): config: got = %q, want = %q", config, tc.wantConfig)
+			}
+
+			if level != tc.wantLevel {
+				t.Errorf("Run(): level: got = %v, want = %v", level, tc.wantLevel)
+			}
+		})
+	}
+}
+
+func TestApp_Run_aliases(t *testing.T) {
+	tt := []struct {
+		name     string
+		args     []string
+		prefixes bool
+		want     string
+		wantErr  error
+	}{
+		{
+			name: "name",
+			args: []string{"remove"},
+			want: "remove",
+		},
+		{
+			name: "alias",
+			args: []string{"rm"},
+			want: "remove",
+		},
+		{
+			name:    "prefix disabled",
+			args:    []string{"rem"},
+			wantErr: &ParseArgError{Arg: "rem", Index: 0, Err: ErrUnknown},
+		},
+		{
+			name:     "prefix",
+			args:     []string{"rem"},
+			prefixes: true,
+			want:     "remove",
+		},
+		{
+			name:     "alias prefix",
+			args:     []string{"ls"},
+			prefixes: true,
+			want:     "list",
+		},
+		{
+			name:     "ambiguous prefix",
+			args:     []string{"cl"},
+			prefixes: true,
+			wantErr:  &AmbiguousCommandError{Name: "cl"},
+		},
+		{
+			name:     "hidden",
+			args:     []string{"de"},
+			prefixes: true,
+			wantErr:  &ParseArgError{Arg: "de", Index: 0, Err: ErrUnknown},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var got string
+
+			action := ActionFunc(func(cmd *Command) ActionRunner {
+				return func(cmd *Command) error {
+					got = cmd.Name
+					return nil
+				}
+			})
+
+			app := App{
+				Name:   "test",
+				Args:   append([]string{}, tc.args...),
+				Parser: &DefaultParser{CommandPrefixes: tc.prefixes},
+				Commands: []Command{
+					{Name: "clone", Action: action},
+					{Name: "clean", Action: action},
+					{Name: "remove", Aliases: []string{"rm"}, Action: action},
+					{Name: "list", Aliases: []string{"ls"}, Action: action},
+					{Name: "debug", Hidden: true, Action: action},
+				},
+			}
+
+			err := app.Run()
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("Run(): got error = %q, want error = %q", err, tc.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Run(): failed to run: %s", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("Run(): command: got = %q, want = %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestApp_handleError_ambiguous(t *testing.T) {
+	var app App
+
+	var buf strings.Builder
+	_ = app.handleError(&AmbiguousCommandError{
+		Name:       "cl",
+		Candidates: []string{"clone", "clean"},
+	}, &buf)
+
+	assertStringsDiff(t, buf.String(), `Ambiguous command: cl
+Did you mean one of these?
+    clone
+    clean
+`)
+}
+
+func TestApp_Run_hidden(t *testing.T) {
+	var (
+		debug bool
+		ran   bool
+	)
+
+	app := App{
+		Name: "test",
+		Args: []string{"dump", "--debug"},
+		Commands: []Command{
+			{
+				Name:   "dump",
+				Hidden: true,
+				Action: ActionFunc(func(cmd *Command) ActionRunner {
+					_ = BoolVar(cmd, &debug, "debug", Hidden)
+
+					return func(cmd *Command) error {
+						ran = true
+						return nil
+					}
+				}),
+			},
+		},
+	}
+
+	if err := app.Run(); err != nil {
+		t.Fatalf("Run(): failed to run: %s", err)
+	}
+
+	if !ran {
+		t.Errorf("Run(): hidden command was not run")
+	}
+
+	if !debug {
+		t.Errorf("Run(): debug: got = %v, want = %v", debug, true)
+	}
+}
+
+func TestApp_Run_help_all(t *testing.T) {
+	var buf strings.Builder
+
+	app := App{
+		Name:   "test",
+		Args:   []string{"--help-all"},
+		Stdout: &buf,
+		Commands: []Command{
+			{Name: "dump", Hidden: true},
+		},
+		CommandFlags: []CommandFlag{
+			HelpAllCommandFlag(),
+		},
+	}
+
+	if err := app.Run(); err != nil {
+		t.Fatalf("Run(): failed to run: %s", err)
+	}
+
+	if !strings.Contains(buf.String(), "dump") {
+		t.Errorf("Run(): help does not contain a hidden command:\n%s", buf.String())
+	}
+}
+
+func TestApp_Parse(t *testing.T) {
+	tt := []struct {
+		name            string
+		args            []string
+		wantPath        []string
+		wantFlags       []string
+		wantArgs        []string
+		wantRest        []string
+		wantCommandFlag string
+		wantUnknown     []string
+		wantPassthrough []string
+	}{
+		{
+			name:     "root",
+			args:     []string{},
+			wantPath: []string{"test"},
+		},
+		{
+			name:        "subcommand",
+			args:        []string{"--verbose", "sub", "--jobs", "4", "target", "--unknown"},
+			wantPath:    []string{"test", "sub"},
+			wantFlags:   []string{"jobs", "verbose"},
+			wantArgs:    []string{"target"},
+			wantUnknown: []string{"--unknown"},
+		},
+		{
+			name:            "command flag",
+			args:            []string{"sub", "--help"},
+			wantPath:        []string{"test", "sub"},
+			wantFlags:       []string{"help"},
+			wantCommandFlag: "help",
+		},
+		{
+			name:            "passthrough",
+			args:            []string{"sub", "target", "--", "prog", "--arg"},
+			wantPath:        []string{"test", "sub"},
+			wantArgs:        []string{"target"},
+			wantPassthrough: []string{"prog", "--arg"},
+		},
+		{
+			name:     "rest",
+			args:     []string{"sub", "target", "a", "b"},
+			wantPath: []string{"test", "sub"},
+			wantArgs: []string{"target"},
+			wantRest: []string{"a", "b"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			failRun := func(cmd *Command) error {
+				t.Errorf("Parse(): action of %v has been run", cmd.Path())
+				return nil
+			}
+
+			app := App{
+				Name: "test",
+				Parser: &DefaultParser{
+					IgnoreUnknownFlags: true,
+				},
+				CommandFlags: []CommandFlag{
+					{
+						Long:   "help",
+						Action: ActionRunner(failRun),
+					},
+				},
+				Action: ActionFunc(func(cmd *Command) ActionRunner {
+					_ = Bool(cmd, "verbose", Global)
+
+					return failRun
+				}),
+				Commands: []Command{
+					{
+						Name: "sub",
+						Action: ActionFunc(func(cmd *Command) ActionRunner {
+							_ = Int(cmd, "jobs")
+							_ = StringArg(cmd, "target", Optional)
+							_ = RestStrings(cmd, "files")
+							_ = Passthrough(cmd, "command")
+
+							return failRun
+						}),
+					},
+				},
+			}
+
+			res, err := app.Parse(context.Background(), append([]string{}, tc.args...))
+			if err != nil {
+				t.Fatalf("Parse(): failed to parse: %s", err)
+			}
+
+			if got := res.Command.Path(); !reflect.DeepEqual(got, tc.wantPath) {
+				t.Errorf("Parse(): path: got = %v, want = %v", got, tc.wantPath)
+			}
+
+			var flags []string
+			for _, flag := range res.Flags {
+				flags = append(flags, flag.Long)
+			}
+
+			if !reflect.DeepEqual(flags, tc.wantFlags) {
+				t.Errorf("Parse(): flags: got = %v, want = %v", flags, tc.wantFlags)
+			}
+
+			var args []string
+			for _, arg := range res.Args {
+				args = append(args, arg.Name)
+			}
+
+			if !reflect.DeepEqual(args, tc.wantArgs) {
+				t.Errorf("Parse(): args: got = %v, want = %v", args, tc.wantArgs)
+			}
+
+			var rest []string
+			if res.Rest != nil {
+				rest = res.Rest.Values.(Getter).Get().([]string)
+			}
+
+			if !reflect.DeepEqual(rest, tc.wantRest) {
+				t.Errorf("Parse(): rest: got = %v, want = %v", rest, tc.wantRest)
+			}
+
+			var commandFlag string
+			if res.CommandFlag != nil {
+				commandFlag = res.CommandFlag.Long
+			}
+
+			if commandFlag != tc.wantCommandFlag {
+				t.Errorf("Parse(): command flag: got = %q, want = %q", commandFlag, tc.wantCommandFlag)
+			}
+
+			if !reflect.DeepEqual(res.Unknown, tc.wantUnknown) {
+				t.Errorf("Parse(): unknown: got = %#v, want = %#v", res.Unknown, tc.wantUnknown)
+			}
+
+			if !reflect.DeepEqual(res.Passthrough, tc.wantPassthrough) {
+				t.Errorf("Parse(): passthrough: got = %#v, want = %#v", res.Passthrough, tc.wantPassthrough)
+			}
+		})
+	}
+}
+
+func TestApp_Parse_many(t *testing.T) {
+	app := App{
+		Name: "test",
+		CommandFlags: []CommandFlag{
+			{
+				Long: "help",
+			},
+		},
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Int(cmd, "jobs", Required)
+
+			return nil
+		}),
+		Commands: []Command{
+			{
+				Name: "sub",
+				Action: ActionFunc(func(cmd *Command) ActionRunner {
+					_ = String(cmd, "name", Required)
+
+					return nil
+				}),
+			},
+		},
+	}
+
+	tt := []struct {
+		name            string
+		args            []string
+		wantFlags       []string
+		wantCommandFlag string
+		wantErr         error
+	}{
+		{
+			name:      "set",
+			args:      []string{"--jobs", "3"},
+			wantFlags: []string{"jobs"},
+		},
+		{
+			name:    "not set",
+			args:    []string{},
+			wantErr: &FlagError{Long: "jobs", Err: ErrNotProvided},
+		},
+		{
+			name:            "command flag",
+			args:            []string{"sub", "--help"},
+			wantFlags:       []string{"help"},
+			wantCommandFlag: "help",
+		},
+		{
+			name:    "subcommand",
+			args:    []string{"sub"},
+			wantErr: &FlagError{Long: "name", Err: ErrNotProvided},
+		},
+		{
+			name:      "subcommand set",
+			args:      []string{"--jobs", "1", "sub", "--name", "x"},
+			wantFlags: []string{"jobs", "name"},
+		},
+	}
+
+	// The same app is used for all command lines.
+	for _, tc := range tt {
+		res, err := app.Parse(context.Background(), tc.args)
+		if tc.wantErr != nil {
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Parse(): %s: got error = %q, want error = %q", tc.name, err, tc.wantErr)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Parse(): %s: failed to parse: %s", tc.name, err)
+		}
+
+		var flags []string
+		for _, flag := range res.Flags {
+			flags = append(flags, flag.Long)
+		}
+
+		if !reflect.DeepEqual(flags, tc.wantFlags) {
+			t.Errorf("Parse(): %s: flags: got = %v, want = %v", tc.name, flags, tc.wantFlags)
+		}
+
+		var commandFlag string
+		if res.CommandFlag != nil {
+			commandFlag = res.CommandFlag.Long
+		}
+
+		if commandFlag != tc.wantCommandFlag {
+			t.Errorf("Parse(): %s: command flag: got = %q, want = %q", tc.name, commandFlag, tc.wantCommandFlag)
+		}
+	}
+}
+
+func TestApp_Run_stop_at_first_arg(t *testing.T) {
+	tt := []struct {
+		name        string
+		args        []string
+		env         map[string]string
+		noPosixly   bool
+		wantVerbose bool
+		wantRest    []string
+	}{
+		{
+			name:     "exec command",
+			args:     []string{"exec", "ls", "-v", "-la"},
+			wantRest: []string{"ls", "-v", "-la"},
+		},
+		{
+			name:        "flags before arg",
+			args:        []string{"exec", "-v", "ls", "-la"},
+			wantVerbose: true,
+			wantRest:    []string{"ls", "-la"},
+		},
+		{
+			name:     "posixly correct",
+			args:     []string{"run", "ls", "-v"},
+			env:      map[string]string{"POSIXLY_CORRECT": ""},
+			wantRest: []string{"ls", "-v"},
+		},
+		{
+			name:        "posixly correct ignored",
+			args:        []string{"run", "ls", "-v"},
+			env:         map[string]string{"POSIXLY_CORRECT": ""},
+			noPosixly:   true,
+			wantVerbose: true,
+			wantRest:    []string{"ls"},
+		},
+		{
+			name:        "interspersed",
+			args:        []string{"run", "ls", "-v"},
+			wantVerbose: true,
+			wantRest:    []string{"ls"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				verbose bool
+				rest    []string
+			)
+
+			action := ActionFunc(func(cmd *Command) ActionRunner {
+				_ = BoolVar(cmd, &verbose, "verbose", WithShort("v"))
+				_ = RestStringsVar(cmd, &rest, "args")
+
+				return func(cmd *Command) error { return nil }
+			})
+
+			app := App{
+				Name: "test",
+				Args: append([]string{}, tc.args...),
+				LookupEnv: func(key string) (string, bool) {
+					v, ok := tc.env[key]
+					return v, ok
+				},
+				Commands: []Command{
+					{
+						Name:           "exec",
+						Action:         action,
+						StopAtFirstArg: true,
+					},
+					{
+						Name:   "run",
+						Action: action,
+					},
+				},
+			}
+
+			// The default parser honors POSIXLY_CORRECT.
+			if tc.noPosixly {
+				app.Parser = &DefaultParser{IgnorePosixlyCorrect: true}
+			}
+
+			if err := app.Run(); err != nil {
+				t.Fatalf("Run(): failed to run: %s", err)
+			}
+
+			if verbose != tc.wantVerbose {
+				t.Errorf("Run(): verbose: got = %v, want = %v", verbose, tc.wantVerbose)
+			}
+
+			if !reflect.DeepEqual(rest, tc.wantRest) {
+				t.Errorf("Run(): args: got = %#v, want = %#v", rest, tc.wantRest)
+			}
+		})
+	}
+}
+
+func TestApp_Run_prefix_with_args(t *testing.T) {
+	var (
+		command string
+		name    *string
+	)
+
+	action := ActionFunc(func(cmd *Command) ActionRunner {
+		return func(cmd *Command) error {
+			command = cmd.Name
+			return nil
+		}
+	})
+
+	app := App{
+		Name:   "test",
+		Args:   []string{"cl"},
+		Parser: &DefaultParser{CommandPrefixes: true},
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			name = StringArg(cmd, "name", Optional)
+
+			return func(cmd *Command) error {
+				command = cmd.Name
+				return nil
+			}
+		}),
+		Commands: []Command{
+			{Name: "clone", Action: action},
+			{Name: "clean", Action: action},
+		},
+	}
+
+	if err := app.Run(); err != nil {
+		t.Fatalf("Run(): failed to run: %s", err)
+	}
+
+	if command != "test" {
+		t.Errorf("Run(): command: got = %q, want = %q", command, "test")
+	}
+
+	if *name != "cl" {
+		t.Errorf("Run(): name: got = %q, want = %q", *name, "cl")
+	}
+}
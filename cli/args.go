@@ -5,6 +5,11 @@ type Arg struct {
 	Name      string
 	Usage     Usager
 	Necessary Necessary
+	Hidden    bool
+
+	Deprecation *Deprecation
+
+	Validators []Validator
 
 	set          bool
 	defaultSaved bool
@@ -18,6 +23,11 @@ func newArg(value Value, opts ArgOptions) Arg {
 		Name:      opts.Name,
 		Usage:     opts.Usage,
 		Necessary: opts.Necessary,
+		Hidden:    opts.Hidden,
+
+		Deprecation: opts.Deprecation,
+
+		Validators: opts.Validators,
 	}
 }
 
@@ -65,6 +75,38 @@ func (a *Arg) String() string {
 	return "Arg(" + a.Type() + "," + a.Name + ")"
 }
 
+func visibleArgs(args []Arg) []Arg {
+	res := make([]Arg, 0, len(args))
+	for i := range args {
+		if !args[i].Hidden {
+			res = append(res, args[i])
+		}
+	}
+
+	return res
+}
+
+func actualArgs(args []Arg) []Arg {
+	res := make([]Arg, 0, len(args))
+	for i := range args {
+		if args[i].Deprecation == nil {
+			res = append(res, args[i])
+		}
+	}
+
+	return res
+}
+
+func findArg(args []Arg, name string) (idx int, arg *Arg, ok bool) {
+	for i := range args {
+		if args[i].Name == name {
+			return i, &args[i], true
+		}
+	}
+
+	return -1, nil, false
+}
+
 func ArgVar(register Register, value Value, name string, options ...ArgOptionApplyer) error {
 	var opts ArgOptions
 	opts.applyName(name)
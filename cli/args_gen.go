@@ -3,6 +3,8 @@
 package cli
 
 import (
+	"net"
+	"net/url"
 	"time"
 )
 
@@ -605,3 +607,123 @@ func DurationArg(register Register, name string, options ...ArgOptionApplyer) *t
 	_ = DurationArgVar(register, p, name, options...)
 	return p
 }
+
+// net.IP
+
+// IPArgVar defines a net.IP argument with specified name.
+// The argument p points to a net.IP variable in which to store the value of the argument.
+// The return value will be an error from the register.RegisterArg if it
+// failed to register the argument.
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.IPArgVar(register, &p, "name", cli.Usage("The name of user"))
+//
+// The argument is required by default.
+// This may be changed by passing the cli.Optional.
+//
+//   _ = cli.IPArgVar(register, &p, "name", cli.Optional)
+//
+// All options can be used together.
+func IPArgVar(register Register, p *net.IP, name string, options ...ArgOptionApplyer) error {
+	return ArgVar(register, newIPValue(p), name, options...)
+}
+
+// IPArg defines a net.IP argument with specified name.
+// The return value is the address of a net.IP variable that stores the value of the argument.
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.IPArg(register, "name", cli.Usage("The name of user"))
+//
+// The argument is required by default.
+// This may be changed by passing the cli.Optional.
+//
+//   _ = cli.IPArg(register, "name", cli.Optional)
+//
+// All options can be used together.
+func IPArg(register Register, name string, options ...ArgOptionApplyer) *net.IP {
+	p := new(net.IP)
+	_ = IPArgVar(register, p, name, options...)
+	return p
+}
+
+// net.IPNet
+
+// IPNetArgVar defines a net.IPNet argument with specified name.
+// The argument p points to a net.IPNet variable in which to store the value of the argument.
+// The return value will be an error from the register.RegisterArg if it
+// failed to register the argument.
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.IPNetArgVar(register, &p, "name", cli.Usage("The name of user"))
+//
+// The argument is required by default.
+// This may be changed by passing the cli.Optional.
+//
+//   _ = cli.IPNetArgVar(register, &p, "name", cli.Optional)
+//
+// All options can be used together.
+func IPNetArgVar(register Register, p *net.IPNet, name string, options ...ArgOptionApplyer) error {
+	return ArgVar(register, newIPNetValue(p), name, options...)
+}
+
+// IPNetArg defines a net.IPNet argument with specified name.
+// The return value is the address of a net.IPNet variable that stores the value of the argument.
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.IPNetArg(register, "name", cli.Usage("The name of user"))
+//
+// The argument is required by default.
+// This may be changed by passing the cli.Optional.
+//
+//   _ = cli.IPNetArg(register, "name", cli.Optional)
+//
+// All options can be used together.
+func IPNetArg(register Register, name string, options ...ArgOptionApplyer) *net.IPNet {
+	p := new(net.IPNet)
+	_ = IPNetArgVar(register, p, name, options...)
+	return p
+}
+
+// url.URL
+
+// URLArgVar defines a url.URL argument with specified name.
+// The argument p points to a url.URL variable in which to store the value of the argument.
+// The return value will be an error from the register.RegisterArg if it
+// failed to register the argument.
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.URLArgVar(register, &p, "name", cli.Usage("The name of user"))
+//
+// The argument is required by default.
+// This may be changed by passing the cli.Optional.
+//
+//   _ = cli.URLArgVar(register, &p, "name", cli.Optional)
+//
+// All options can be used together.
+func URLArgVar(register Register, p *url.URL, name string, options ...ArgOptionApplyer) error {
+	return ArgVar(register, newURLValue(p), name, options...)
+}
+
+// URLArg defines a url.URL argument with specified name.
+// The return value is the address of a url.URL variable that stores the value of the argument.
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.URLArg(register, "name", cli.Usage("The name of user"))
+//
+// The argument is required by default.
+// This may be changed by passing the cli.Optional.
+//
+//   _ = cli.URLArg(register, "name", cli.Optional)
+//
+// All options can be used together.
+func URLArg(register Register, name string, options ...ArgOptionApplyer) *url.URL {
+	p := new(url.URL)
+	_ = URLArgVar(register, p, name, options...)
+	return p
+}
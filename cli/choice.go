@@ -0,0 +1,251 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidChoice = errors.New("invalid choice")
+
+	ErrNoChoices = errors.New("no choices")
+)
+
+// ChoiceItem is an allowed value of a choice with an optional description.
+type ChoiceItem struct {
+	Value       string
+	Description string
+}
+
+// Choices is a list of allowed values of a choice flag, arg or rest args.
+//
+//   cli.Choices{
+//     {Value: "json", Description: "JSON output"},
+//     {Value: "yaml", Description: "YAML output"},
+//   }
+type Choices []ChoiceItem
+
+// ChoicesOf returns choices without descriptions.
+//
+//   cli.ChoicesOf("json", "yaml", "table")
+func ChoicesOf(values ...string) Choices {
+	choices := make(Choices, len(values))
+	for i, v := range values {
+		choices[i].Value = v
+	}
+
+	return choices
+}
+
+// Values returns allowed values.
+func (c Choices) Values() []string {
+	values := make([]string, len(c))
+	for i := range c {
+		values[i] = c[i].Value
+	}
+
+	return values
+}
+
+func (c Choices) has(value string) bool {
+	for i := range c {
+		if c[i].Value == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c Choices) check(value string) error {
+	if c.has(value) {
+		return nil
+	}
+
+	s := newSuggester(value)
+	for i := range c {
+		s.Add(c[i].Value, c[i].Value)
+	}
+
+	return &ParseValueError{
+		Type: "choice",
+		Err: &ChoiceError{
+			Value:       value,
+			Choices:     c.Values(),
+			Suggestions: s.Suggestions(),
+		},
+	}
+}
+
+// checkChoices checks that choice values have at least one choice.
+func checkChoices(value Value) error {
+	if c, ok := value.(Chooser); ok && len(c.Choices()) == 0 {
+		return ErrNoChoices
+	}
+
+	return nil
+}
+
+// typ returns the type of the choice in the "{json|yaml|table}" form.
+func (c Choices) typ() string {
+	return "{" + strings.Join(c.Values(), "|") + "}"
+}
+
+type ChoiceError struct {
+	Value       string
+	Choices     []string // Allowed values.
+	Suggestions []string // Similar allowed values.
+}
+
+func (e *ChoiceError) Error() string {
+	return fmt.Sprintf("invalid choice '%s': must be one of %s", e.Value, strings.Join(e.Choices, ", "))
+}
+
+func (e *ChoiceError) Unwrap() error { return ErrInvalidChoice }
+
+func (e *ChoiceError) Is(err error) bool {
+	ce, ok := err.(*ChoiceError)
+	return ok && ce.Value == e.Value
+}
+
+// Chooser is an optional interface of a Value. It returns allowed values for
+// help and completions.
+type Chooser interface {
+	Value
+	Choices() Choices
+}
+
+var (
+	_ Value   = (*choiceValue)(nil)
+	_ Getter  = (*choiceValue)(nil)
+	_ Emptier = (*choiceValue)(nil)
+	_ Typer   = (*choiceValue)(nil)
+	_ Chooser = (*choiceValue)(nil)
+)
+
+type choiceValue struct {
+	p       *string
+	choices Choices
+}
+
+func newChoiceValue(p *string, choices Choices) *choiceValue {
+	return &choiceValue{p: p, choices: choices}
+}
+
+func (v *choiceValue) Set(val string) error {
+	if err := v.choices.check(val); err != nil {
+		return err
+	}
+
+	*v.p = val
+	return nil
+}
+
+func (v *choiceValue) Get() interface{} { return *v.p }
+
+func (v *choiceValue) Empty() bool { return *v.p == "" }
+
+func (v *choiceValue) String() string { return *v.p }
+
+func (v *choiceValue) Type() string { return v.choices.typ() }
+
+func (v *choiceValue) Choices() Choices { return v.choices }
+
+var (
+	_ Value      = (*choiceValues)(nil)
+	_ Getter     = (*choiceValues)(nil)
+	_ Emptier    = (*choiceValues)(nil)
+	_ Typer      = (*choiceValues)(nil)
+	_ Chooser    = (*choiceValues)(nil)
+	_ sliceValue = (*choiceValues)(nil)
+)
+
+type choiceValues struct {
+	p       *[]string
+	choices Choices
+}
+
+func newChoiceValues(p *[]string, choices Choices) *choiceValues {
+	return &choiceValues{p: p, choices: choices}
+}
+
+func (vs *choiceValues) Set(val string) error {
+	if err := vs.choices.check(val); err != nil {
+		return err
+	}
+
+	*vs.p = append(*vs.p, val)
+	return nil
+}
+
+func (vs *choiceValues) Get() interface{} { return *vs.p }
+
+func (vs *choiceValues) Empty() bool { return len(*vs.p) == 0 }
+
+func (vs *choiceValues) String() string { return strings.Join(*vs.p, ",") }
+
+func (vs *choiceValues) Type() string { return vs.choices.typ() }
+
+func (*choiceValues) IsSliceValue() bool { return true }
+
+func (vs *choiceValues) Choices() Choices { return vs.choices }
+
+// ChoiceVar defines a string flag with specified name and allowed values.
+func ChoiceVar(register Register, p *string, name string, choices Choices, options ...FlagOptionApplyer) error {
+	return Var(register, newChoiceValue(p, choices), name, options...)
+}
+
+// Choice defines a string flag with specified name and allowed values.
+// The return value is the address of a string variable that stores the value
+// of the flag.
+//
+//   format := cli.Choice(register, "format", cli.ChoicesOf("json", "yaml"))
+func Choice(register Register, name string, choices Choices, options ...FlagOptionApplyer) *string {
+	p := new(string)
+	_ = ChoiceVar(register, p, name, choices, options...)
+	return p
+}
+
+// ChoiceArgVar defines a string argument with specified name and allowed
+// values.
+func ChoiceArgVar(register Register, p *string, name string, choices Choices, options ...ArgOptionApplyer) error {
+	return ArgVar(register, newChoiceValue(p, choices), name, options...)
+}
+
+// ChoiceArg defines a string argument with specified name and allowed values.
+// The return value is the address of a string variable that stores the value
+// of the argument.
+//
+//   shell := cli.ChoiceArg(register, "shell", cli.ChoicesOf("bash", "zsh"))
+func ChoiceArg(register Register, name string, choices Choices, options ...ArgOptionApplyer) *string {
+	p := new(string)
+	_ = ChoiceArgVar(register, p, name, choices, options...)
+	return p
+}
+
+// RestChoicesVar defines the []string rest arguments with specified name and
+// allowed values.
+func RestChoicesVar(register Register, p *[]string, name string, choices Choices, options ...RestOptionApplyer) error {
+	return RestVar(register, newChoiceValues(p, choices), name, options...)
+}
+
+// RestChoices defines the []string rest arguments with specified name and
+// allowed values.
+// The return value is the address of a []string variable that stores values
+// of arguments.
+//
+//   targets := cli.RestChoices(register, "targets", cli.ChoicesOf("linux", "darwin"))
+func RestChoices(register Register, name string, choices Choices, options ...RestOptionApplyer) *[]string {
+	p := new([]string)
+	_ = RestChoicesVar(register, p, name, choices, options...)
+	return p
+}
+
+func valueChoices(v Value) (Choices, bool) {
+	if c, ok := v.(Chooser); ok {
+		return c.Choices(), true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParser_Parse_choice(t *testing.T) {
+	formats := ChoicesOf("json", "yaml", "table")
+
+	tt := []struct {
+		name        string
+		args        []string
+		wantFormat  string
+		wantShell   string
+		wantTargets []string
+		wantErr     error
+	}{
+		{
+			name:        "valid",
+			args:        []string{"--format", "yaml", "zsh", "linux", "darwin"},
+			wantFormat:  "yaml",
+			wantShell:   "zsh",
+			wantTargets: []string{"linux", "darwin"},
+		},
+		{
+			name:    "invalid flag",
+			args:    []string{"--format", "jsn", "zsh"},
+			wantErr: &FlagError{Long: "format", Err: &ParseValueError{Type: "choice", Err: ErrInvalidChoice}},
+		},
+		{
+			name:    "invalid arg",
+			args:    []string{"fish"},
+			wantErr: &ArgError{Name: "shell", Err: &ParseValueError{Type: "choice", Err: ErrInvalidChoice}},
+		},
+		{
+			name:    "invalid rest",
+			args:    []string{"zsh", "linux", "windows"},
+			wantErr: &ArgError{Name: "targets", Index: 2, Err: &ParseValueError{Type: "choice", Err: ErrInvalidChoice}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			format := Choice(&register, "format", formats)
+			shell := ChoiceArg(&register, "shell", ChoicesOf("bash", "zsh"))
+			targets := RestChoices(&register, "targets", ChoicesOf("linux", "darwin"))
+
+			err := parser.Parse(nil, &register, tc.args)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("Parse(): got error = %q, want error = %q", err, tc.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Parse(): failed to parse args: %s", err)
+			}
+
+			if *format != tc.wantFormat {
+				t.Errorf("Parse(): format: got = %q, want = %q", *format, tc.wantFormat)
+			}
+
+			if *shell != tc.wantShell {
+				t.Errorf("Parse(): shell: got = %q, want = %q", *shell, tc.wantShell)
+			}
+
+			if !reflect.DeepEqual(*targets, tc.wantTargets) {
+				t.Errorf("Parse(): targets: got = %#v, want = %#v", *targets, tc.wantTargets)
+			}
+		})
+	}
+}
+
+func TestRegister_choice_empty(t *testing.T) {
+	tt := []struct {
+		name     string
+		register func(r Register)
+		want     error
+	}{
+		{
+			name:     "flag",
+			register: func(r Register) { _ = Choice(r, "format", nil) },
+			want:     &FlagError{Long: "format", Err: ErrNoChoices},
+		},
+		{
+			name:     "arg",
+			register: func(r Register) { _ = ChoiceArg(r, "shell", Choices{}) },
+			want:     &ArgError{Name: "shell", Err: ErrNoChoices},
+		},
+		{
+			name:     "rest",
+			register: func(r Register) { _ = RestChoices(r, "shells", nil) },
+			want:     &RestArgsError{Name: "shells", Err: ErrNoChoices},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var register DefaultRegister
+
+			tc.register(&register)
+
+			if err := register.Err(); !errors.Is(err, tc.want) {
+				t.Errorf("Err(): got error = %q, want error = %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestChoiceError(t *testing.T) {
+	var format string
+	value := newChoiceValue(&format, ChoicesOf("json", "yaml", "table"))
+
+	err := value.Set("jsn")
+
+	var choiceErr *ChoiceError
+	if !errors.As(err, &choiceErr) {
+		t.Fatalf("Set(): got error = %q, want ChoiceError", err)
+	}
+
+	wantChoices := []string{"json", "yaml", "table"}
+	if !reflect.DeepEqual(choiceErr.Choices, wantChoices) {
+		t.Errorf("Set(): choices: got = %v, want = %v", choiceErr.Choices, wantChoices)
+	}
+
+	wantSuggestions := []string{"json"}
+	if !reflect.DeepEqual(choiceErr.Suggestions, wantSuggestions) {
+		t.Errorf("Set(): suggestions: got = %v, want = %v", choiceErr.Suggestions, wantSuggestions)
+	}
+
+	const want = "parse choice error: invalid choice 'jsn': must be one of json, yaml, table"
+	if got := err.Error(); got != want {
+		t.Errorf("Set(): got = %q, want = %q", got, want)
+	}
+}
+
+func TestApp_handleError_choice(t *testing.T) {
+	var app App
+
+	var buf strings.Builder
+	_ = app.handleError(&FlagError{
+		Long: "format",
+		Err: &ParseValueError{
+			Type: "choice",
+			Err: &ChoiceError{
+				Value:       "jsn",
+				Choices:     []string{"json", "yaml"},
+				Suggestions: []string{"json"},
+			},
+		},
+	}, &buf)
+
+	assertStringsDiff(t, buf.String(), `Invalid --format flag value: parse choice error: invalid choice 'jsn': must be one of json, yaml
+Did you mean json?
+`)
+}
+
+func TestDefaultHelper_Help_choice(t *testing.T) {
+	app := App{
+		Name: "choice",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Choice(cmd, "format", ChoicesOf("json", "yaml", "table"),
+				WithShort("f"),
+				Usage("Output format"),
+			)
+
+			_ = ChoiceArg(cmd, "shell", ChoicesOf("bash", "zsh"))
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+	}
+
+	cmd, err := app.Command("choice")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := (DefaultHelper{}).Help(cmd, &buf); err != nil {
+		t.Fatalf("Help(): failed to write help: %s", err)
+	}
+
+	assertContains(t, buf.String(),
+		"<shell> {bash|zsh}",
+		"-f, --format {json|yaml|table}",
+	)
+}
+
+func TestZSHCompletionGenerator_choice(t *testing.T) {
+	app := &App{
+		Name: "test",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Choice(cmd, "format", Choices{
+				{Value: "json", Description: "JSON output"},
+				{Value: "yaml"},
+			})
+
+			_ = ChoiceArg(cmd, "shell", ChoicesOf("bash", "zsh"))
+			_ = RestChoices(cmd, "targets", ChoicesOf("linux", "darwin"))
+
+			return nil
+		}),
+	}
+
+	got := generateZSHCompletion(t, app)
+
+	assertContains(t, got,
+		`--format'='': :((json\:JSON\ output yaml))'`,
+		`'1:shell:(bash zsh)'`,
+		`'*::targets:(linux darwin)'`,
+	)
+}
@@ -66,6 +66,16 @@ func HelpCommandFlag() CommandFlag {
 	}
 }
 
+func HelpAllCommandFlag() CommandFlag {
+	return CommandFlag{
+		Long:  "help-all",
+		Usage: Usage("Show information about a command including hidden items"),
+		Action: ActionRunner(func(cmd *Command) error {
+			return cmd.App().HelpAll(cmd, cmd.Stdout())
+		}),
+	}
+}
+
 func VersionCommandFlag(version string) CommandFlag {
 	return CommandFlag{
 		Long:  "version",
@@ -5,6 +5,7 @@ package cli
 
 import (
 	"io"
+	"strings"
 )
 
 type CompletionGenerator interface {
@@ -105,9 +106,11 @@ func (g *ZSHCompletionGenerator) CompletionGenerate(cmd *Command, w io.Writer) e
 func (g *ZSHCompletionGenerator) generateCommand(cmd *Command, ew *easyWriter) error {
 	app := cmd.App()
 	path := cmd.Path()
-	flags := cmd.Flags()
-	args := cmd.Args()
+	flags := visibleFlags(cmd.Flags())
+	allArgs := cmd.Args()
+	args := visibleArgs(allArgs)
 	rest := cmd.Rest()
+	commands := visibleCommands(cmd.Commands)
 
 	ew.Writef("    #")
 	for _, p := range path {
@@ -122,7 +125,7 @@ func (g *ZSHCompletionGenerator) generateCommand(cmd *Command, ew *easyWriter) e
 	ew.Writef("() {\n")
 
 	// Empty command.
-	if len(flags) == 0 && len(args) == 0 && rest == nil && len(cmd.Commands) == 0 {
+	if len(flags) == 0 && len(args) == 0 && rest == nil && len(commands) == 0 {
 		ew.Writef("        :")
 		ew.Writef("    }\n")
 
@@ -156,7 +159,7 @@ func (g *ZSHCompletionGenerator) generateCommand(cmd *Command, ew *easyWriter) e
 
 	ew.Writef("        local -a options args rest commands\n")
 
-	if len(cmd.Commands) > 0 {
+	if len(commands) > 0 {
 		ew.Writef("        local subcmd\n")
 	}
 
@@ -171,13 +174,23 @@ func (g *ZSHCompletionGenerator) generateCommand(cmd *Command, ew *easyWriter) e
 		for i := range flags {
 			f := &flags[i]
 
+			// Keys of objects are unknown.
+			if fv, ok := f.Value.(objectFlag); ok && fv.IsObjectFlag() {
+				continue
+			}
+
 			if f.Short != "" || f.Long != "" {
 				ew.Writef("            ")
-				if err := g.generateFlagDef(cmd, f, len(args), ew); err != nil {
+				if err := g.generateFlagDef(cmd, f, len(allArgs), ew); err != nil {
 					return err
 				}
 				ew.Writef("\n")
 			}
+
+			// The "--<long>-file" form takes a path.
+			for _, name := range f.FileLongs() {
+				ew.Writef("            %s'=: :_files'\n", cmd.Parser().FormatLongFlag(name))
+			}
 		}
 		ew.Writef("        )\n")
 
@@ -191,8 +204,13 @@ func (g *ZSHCompletionGenerator) generateCommand(cmd *Command, ew *easyWriter) e
 		ew.Writef("\n")
 
 		ew.Writef("        args=(\n")
-		for i := range args {
-			arg := &args[i]
+		for i := range allArgs {
+			arg := &allArgs[i]
+
+			// Keep positions of visible args.
+			if arg.Hidden {
+				continue
+			}
 
 			ew.Writef("            ")
 			if err := g.generateArgDef(i, arg, ew); err != nil {
@@ -220,23 +238,25 @@ func (g *ZSHCompletionGenerator) generateCommand(cmd *Command, ew *easyWriter) e
 	}
 
 	// Commands.
-	if len(cmd.Commands) > 0 {
+	if len(commands) > 0 {
 		ew.Writef("\n")
 		ew.Writef("        commands=(\n")
-		for i := range cmd.Commands {
+		for i := range commands {
 			// TODO(SuperPaintman): optimize path.
-			newPath := append(append([]string{}, path...), cmd.Commands[i].Name)
+			newPath := append(append([]string{}, path...), commands[i].Name)
 
 			subCmd, err := app.Command(newPath...)
 			if err != nil {
 				return err
 			}
 
-			ew.Writef("            ")
-			if err := g.generateCommandDef(subCmd, ew); err != nil {
-				return err
+			for _, name := range append([]string{subCmd.Name}, subCmd.Aliases...) {
+				ew.Writef("            ")
+				if err := g.generateCommandDef(subCmd, name, ew); err != nil {
+					return err
+				}
+				ew.Writef("\n")
 			}
-			ew.Writef("\n")
 		}
 		ew.Writef("        )\n")
 
@@ -247,7 +267,7 @@ func (g *ZSHCompletionGenerator) generateCommand(cmd *Command, ew *easyWriter) e
 
 	// Arguments and subcommands.
 	ew.Writef("\n")
-	if len(cmd.Commands) == 0 {
+	if len(commands) == 0 {
 		ew.Writef("        _arguments $options $rest $args\n")
 	} else {
 		// Detect mode.
@@ -260,10 +280,13 @@ func (g *ZSHCompletionGenerator) generateCommand(cmd *Command, ew *easyWriter) e
 		}
 		ew.Writef("        else\n")
 		ew.Writef("            case \"${words[2]}\" in\n")
-		ew.Writef("                '%s'", cmd.Commands[0].Name)
+		ew.Writef("                ")
+		for i, subCmd := range commands {
+			if i > 0 {
+				ew.Writef(" | ")
+			}
 
-		for i := range cmd.Commands[1:] {
-			ew.Writef(" | '%s'", cmd.Commands[i+1].Name)
+			g.writeCommandNames(subCmd, ew)
 		}
 		ew.Writef(")\n")
 		ew.Writef("                    subcmd=\"${words[2]}\"\n")
@@ -283,11 +306,11 @@ func (g *ZSHCompletionGenerator) generateCommand(cmd *Command, ew *easyWriter) e
 
 		ew.Writef("\n")
 		ew.Writef("        case \"$subcmd\" in")
-		for i := range cmd.Commands {
-			subCmd := &cmd.Commands[i]
-
+		for _, subCmd := range commands {
 			ew.Writef("\n")
-			ew.Writef("            '%s')\n", subCmd.Name)
+			ew.Writef("            ")
+			g.writeCommandNames(subCmd, ew)
+			ew.Writef(")\n")
 			ew.Writef("                ")
 			if err := g.functionName(subCmd.Path(), ew); err != nil {
 				return err
@@ -316,37 +339,37 @@ func (g *ZSHCompletionGenerator) generateFlagDef(cmd *Command, f *Flag, argsCoun
 			ew.Writef(" %d", i+1)
 		}
 		ew.Writef(")'")
+	} else if isCountFlag(f.Value) {
+		// Counters can be repeated.
+		ew.Writef("'*'")
 	} else if fv, ok := f.Value.(boolFlag); ok && fv.IsBoolFlag() {
+		// All names and both forms of a negatable flag are mutually exclusive.
 		ew.Writef("'(")
-		if f.Short != "" {
-			ew.Writef(cmd.Parser().FormatShortFlag(f.Short))
-		}
-		if f.Long != "" {
-			if f.Long != "" {
-				ew.Writef(" ")
-			}
-			ew.Writef(cmd.Parser().FormatLongFlag(f.Long))
-		}
+		ew.Writef(strings.Join(g.flagNames(cmd, f), " "))
 		ew.Writef(")'")
 	}
 
 	// Name.
-	if f.Short != "" {
-		if f.Long != "" {
-			ew.Writef("{")
-			ew.Writef(cmd.Parser().FormatShortFlag(f.Short))
-			ew.Writef(",")
-			ew.Writef(cmd.Parser().FormatLongFlag(f.Long))
-			ew.Writef("}")
-		} else {
-			ew.Writef(cmd.Parser().FormatShortFlag(f.Short))
+	names := g.flagNames(cmd, f)
+
+	if len(names) > 1 {
+		ew.Writef("{")
+		for i, name := range names {
+			if i > 0 {
+				ew.Writef(",")
+			}
+			ew.Writef(name)
 		}
-	} else {
-		ew.Writef(cmd.Parser().FormatLongFlag(f.Long))
+		ew.Writef("}")
+	} else if len(names) == 1 {
+		ew.Writef(names[0])
 	}
 
 	// Value.
-	if _, ok := f.Value.(boolFlag); !ok {
+	if f.ImplicitValue != nil {
+		// The value is optional and must be in the same word.
+		ew.Writef("'=-'")
+	} else if _, ok := f.Value.(boolFlag); !ok && !isCountFlag(f.Value) {
 		ew.Writef("'='")
 	}
 
@@ -360,8 +383,20 @@ func (g *ZSHCompletionGenerator) generateFlagDef(cmd *Command, f *Flag, argsCoun
 	}
 
 	// Value.
-	if _, ok := f.Value.(boolFlag); !ok {
-		ew.Writef("': :()'")
+	// The message is the name of the value (if any).
+	message := " "
+	if f.ValueName != "" {
+		message = strings.ReplaceAll(f.ValueName, ":", `\:`)
+	}
+
+	if f.ImplicitValue != nil {
+		ew.Writef("'::")
+		_, _ = (&zshFlagSanitizer{ew}).Write([]byte(message))
+		ew.Writef(":%s'", g.valueAction(f.Value))
+	} else if _, ok := f.Value.(boolFlag); !ok && !isCountFlag(f.Value) {
+		ew.Writef("':")
+		_, _ = (&zshFlagSanitizer{ew}).Write([]byte(message))
+		ew.Writef(":%s'", g.valueAction(f.Value))
 	} else {
 		// TODO
 	}
@@ -373,6 +408,94 @@ func (g *ZSHCompletionGenerator) generateFlagDef(cmd *Command, f *Flag, argsCoun
 	return nil
 }
 
+// valueAction returns an action which completes the value: choices with
+// descriptions ("((json\:JSON yaml))"), choices ("(json yaml)"), files
+// ("_files") or nothing ("()").
+func (*ZSHCompletionGenerator) valueAction(v Value) string {
+	if isFileFlag(v) {
+		return "_files"
+	}
+
+	choices, ok := valueChoices(v)
+	if !ok {
+		return "()"
+	}
+
+	var withDescriptions bool
+	for _, c := range choices {
+		if c.Description != "" {
+			withDescriptions = true
+			break
+		}
+	}
+
+	var buf strings.Builder
+	if withDescriptions {
+		_, _ = buf.WriteString("((")
+	} else {
+		_, _ = buf.WriteString("(")
+	}
+
+	for i, c := range choices {
+		if i > 0 {
+			_, _ = buf.WriteString(" ")
+		}
+
+		_, _ = buf.WriteString(zshEscapeChoice(c.Value))
+
+		if withDescriptions && c.Description != "" {
+			_, _ = buf.WriteString("\\:")
+			_, _ = buf.WriteString(zshEscapeChoice(c.Description))
+		}
+	}
+
+	if withDescriptions {
+		_, _ = buf.WriteString("))")
+	} else {
+		_, _ = buf.WriteString(")")
+	}
+
+	return buf.String()
+}
+
+// zshEscapeChoice escapes special characters of a value or a description in
+// a single-quoted action.
+func zshEscapeChoice(s string) string {
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch c {
+		case '\'':
+			_, _ = buf.WriteString(`'\''`)
+
+		case ' ', '\\', ':', '(', ')', '[', ']', '"', '$', '`', '{', '}', '|', ';', '&', '<', '>', '*', '?', '#', '~':
+			_ = buf.WriteByte('\\')
+			_ = buf.WriteByte(c)
+
+		default:
+			_ = buf.WriteByte(c)
+		}
+	}
+
+	return buf.String()
+}
+
+// flagNames returns all formatted names of the flag: short names, long names
+// and negative forms.
+func (*ZSHCompletionGenerator) flagNames(cmd *Command, f *Flag) []string {
+	var names []string
+	for _, short := range f.ShortNames() {
+		names = append(names, cmd.Parser().FormatShortFlag(short))
+	}
+
+	for _, long := range append(f.LongNames(), f.NegativeLongs()...) {
+		names = append(names, cmd.Parser().FormatLongFlag(long))
+	}
+
+	return names
+}
+
 func (g *ZSHCompletionGenerator) generateArgDef(i int, a *Arg, ew *easyWriter) error {
 	ew.Writef("'%d:", i+1)
 
@@ -387,7 +510,7 @@ func (g *ZSHCompletionGenerator) generateArgDef(i int, a *Arg, ew *easyWriter) e
 	}
 
 	// Value.
-	ew.Writef(":()") // TODO
+	ew.Writef(":%s", g.valueAction(a.Value))
 
 	ew.Writef("'")
 
@@ -408,7 +531,7 @@ func (g *ZSHCompletionGenerator) generateRestDef(ra *RestArgs, ew *easyWriter) e
 	}
 
 	// Value.
-	ew.Writef(":()") // TODO
+	ew.Writef(":%s", g.valueAction(ra.Values))
 
 	ew.Writef("'")
 
@@ -419,10 +542,10 @@ func (g *ZSHCompletionGenerator) generateRestDef(ra *RestArgs, ew *easyWriter) e
 	return nil
 }
 
-func (g *ZSHCompletionGenerator) generateCommandDef(subCmd *Command, ew *easyWriter) error {
+func (g *ZSHCompletionGenerator) generateCommandDef(subCmd *Command, name string, ew *easyWriter) error {
 	ew.Writef("'")
 
-	ew.Writef(subCmd.Name)
+	ew.Writef(name)
 
 	if subCmd.Usage != nil {
 		ew.Writef(":")
@@ -441,6 +564,16 @@ func (g *ZSHCompletionGenerator) generateCommandDef(subCmd *Command, ew *easyWri
 	return nil
 }
 
+// writeCommandNames writes a case pattern with the name and aliases of the
+// command: 'remove' | 'rm'.
+func (*ZSHCompletionGenerator) writeCommandNames(cmd *Command, ew *easyWriter) {
+	ew.Writef("'%s'", cmd.Name)
+
+	for _, alias := range cmd.Aliases {
+		ew.Writef(" | '%s'", alias)
+	}
+}
+
 func (*ZSHCompletionGenerator) functionName(path []string, ew *easyWriter) error {
 	if len(path) == 0 {
 		return nil
@@ -496,6 +629,11 @@ func walkCommands(cmd *Command, pathBuffer *[]string, fn func(*Command) error) e
 	path := cmd.Path()
 
 	for i := range cmd.Commands {
+		// Hidden commands and their subcommands are skipped.
+		if cmd.Commands[i].Hidden {
+			continue
+		}
+
 		*pathBuffer = (*pathBuffer)[:len(path)]
 		*pathBuffer = append(*pathBuffer, cmd.Commands[i].Name)
 
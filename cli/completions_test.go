@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func generateZSHCompletion(t *testing.T, app *App) string {
+	t.Helper()
+
+	root, err := app.RootCommand()
+	if err != nil {
+		t.Fatalf("RootCommand(): failed to get command: %s", err)
+	}
+
+	var (
+		generator ZSHCompletionGenerator
+		buf       strings.Builder
+	)
+	if err := generator.CompletionGenerate(root, &buf); err != nil {
+		t.Fatalf("CompletionGenerate(): failed to generate script: %s", err)
+	}
+
+	return buf.String()
+}
+
+func assertContains(t *testing.T, s string, want ...string) {
+	t.Helper()
+
+	for _, w := range want {
+		if !strings.Contains(s, w) {
+			t.Errorf("%q not found in:\n%s", w, s)
+		}
+	}
+}
+
+func assertNotContains(t *testing.T, s string, unwanted ...string) {
+	t.Helper()
+
+	for _, u := range unwanted {
+		if strings.Contains(s, u) {
+			t.Errorf("%q found in:\n%s", u, s)
+		}
+	}
+}
+
+func TestZSHCompletionGenerator_hidden(t *testing.T) {
+	app := &App{
+		Name: "test",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Bool(cmd, "verbose")
+			_ = Bool(cmd, "debug", Hidden)
+
+			return nil
+		}),
+		Commands: []Command{
+			{Name: "list"},
+			{
+				Name:   "dump",
+				Hidden: true,
+				Commands: []Command{
+					{Name: "state"},
+				},
+			},
+		},
+	}
+
+	got := generateZSHCompletion(t, app)
+
+	assertContains(t, got, "--verbose", "'list'", "__test__list")
+	assertNotContains(t, got, "--debug", "dump", "state")
+}
+
+func TestZSHCompletionGenerator_negatable(t *testing.T) {
+	app := &App{
+		Name: "test",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Bool(cmd, "color", WithShort("c"), Negatable)
+
+			return nil
+		}),
+	}
+
+	got := generateZSHCompletion(t, app)
+
+	assertContains(t, got, "'(-c --color --no-color)'{-c,--color,--no-color}")
+}
+
+func TestZSHCompletionGenerator_aliases(t *testing.T) {
+	app := &App{
+		Name: "test",
+		Commands: []Command{
+			{Name: "list", Aliases: []string{"ls"}, Usage: Usage("List items")},
+			{Name: "remove", Aliases: []string{"rm", "del"}},
+		},
+	}
+
+	got := generateZSHCompletion(t, app)
+
+	assertContains(t, got,
+		"'list:List items'",
+		"'ls:List items'",
+		"'rm'",
+		"'list' | 'ls' | 'remove' | 'rm' | 'del')",
+		"'remove' | 'rm' | 'del')\n",
+	)
+}
+
+func TestZSHCompletionGenerator_flag_aliases(t *testing.T) {
+	app := &App{
+		Name: "test",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Bool(cmd, "dry-run", WithShort("n"), WithAlias("dry"))
+
+			return nil
+		}),
+	}
+
+	got := generateZSHCompletion(t, app)
+
+	assertContains(t, got, "'(-n --dry-run --dry)'{-n,--dry-run,--dry}")
+}
+
+func TestZSHCompletionGenerator_count(t *testing.T) {
+	app := &App{
+		Name: "test",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Count(cmd, "verbose", WithShort("v"))
+
+			return nil
+		}),
+	}
+
+	got := generateZSHCompletion(t, app)
+
+	assertContains(t, got, "'*'{-v,--verbose}\n")
+}
+
+func TestZSHCompletionGenerator_optional_value(t *testing.T) {
+	app := &App{
+		Name: "test",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = String(cmd, "color", OptionalValue("auto"))
+			_ = String(cmd, "format", OptionalValue("json"), WithValueName("fmt"))
+			_ = String(cmd, "output", WithValueName("path"))
+
+			return nil
+		}),
+	}
+
+	got := generateZSHCompletion(t, app)
+
+	assertContains(t, got,
+		"--color'=-'':: :()'",
+		"--format'=-''::fmt:()'",
+		"--output'='':path:()'",
+	)
+}
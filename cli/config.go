@@ -0,0 +1,547 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var ErrUnknownFormat = errors.New("unknown format")
+
+type ConfigError struct {
+	File string
+	Line int // 0 if the error is not related to a line.
+	Err  error
+}
+
+func (e *ConfigError) Error() string {
+	msg := "unknown error"
+	if e.Err != nil {
+		msg = e.Err.Error()
+	}
+
+	return fmt.Sprintf("cli: config error: '%s': %s", e.Location(), msg)
+}
+
+func (e *ConfigError) Unwrap() error { return e.Err }
+
+func (e *ConfigError) Is(err error) bool {
+	ce, ok := err.(*ConfigError)
+	return ok && ce.File == e.File && ce.Line == e.Line && errors.Is(ce.Err, e.Err)
+}
+
+// Location returns the file and the line in the "file:line" form.
+func (e *ConfigError) Location() string {
+	if e.Line == 0 {
+		return e.File
+	}
+
+	return fmt.Sprintf("%s:%d", e.File, e.Line)
+}
+
+// ConfigDecoder decodes a config file into a map. Values of the map are
+// strings, bools, numbers, slices of them or nested maps (sections).
+type ConfigDecoder interface {
+	DecodeConfig(data []byte) (map[string]interface{}, error)
+}
+
+var _ ConfigDecoder = (ConfigDecoderFunc)(nil)
+
+type ConfigDecoderFunc func(data []byte) (map[string]interface{}, error)
+
+func (fn ConfigDecoderFunc) DecodeConfig(data []byte) (map[string]interface{}, error) {
+	return fn(data)
+}
+
+var (
+	// JSONConfigDecoder decodes JSON config files.
+	JSONConfigDecoder ConfigDecoder = ConfigDecoderFunc(decodeJSONConfig)
+
+	// INIConfigDecoder decodes INI config files. It also supports a TOML
+	// subset: sections, "key = value" pairs, quoted strings and arrays.
+	INIConfigDecoder ConfigDecoder = ConfigDecoderFunc(decodeINIConfig)
+)
+
+// Config reads values of flags from files.
+//
+// Keys of a config are long names of flags. Flags of subcommands are in
+// sections named by the path of the command without the app name:
+//
+//   verbose = true
+//
+//   [remote.add]
+//   fetch = true
+//
+// Precedence of values: config files < environment variables < command line.
+type Config struct {
+	// Paths of config files from the lowest precedence to the highest.
+	// Missing files are skipped.
+	Files []string
+
+	// Decoders by file extensions. JSON (".json") and INI (".ini", ".toml")
+	// are used if nil.
+	Decoders map[string]ConfigDecoder
+
+	values map[string][]string
+	files  map[string]string // Files of keys.
+	loaded bool
+}
+
+// DefaultConfigFiles returns paths of system, user and project-local config
+// files with the extension:
+//
+//   /etc/<name>/config<ext>
+//   $XDG_CONFIG_HOME/<name>/config<ext> (or ~/.config/<name>/config<ext>)
+//   .<name><ext>
+//
+// The environment is read with lookupEnv (e.g. App.LookupEnv). os.LookupEnv is
+// used if it's nil.
+func DefaultConfigFiles(name, ext string, lookupEnv func(key string) (string, bool)) []string {
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+
+	files := []string{
+		filepath.Join("/etc", name, "config"+ext),
+	}
+
+	configHome, _ := lookupEnv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			configHome = filepath.Join(home, ".config")
+		}
+	}
+
+	if configHome != "" {
+		files = append(files, filepath.Join(configHome, name, "config"+ext))
+	}
+
+	return append(files, "."+name+ext)
+}
+
+// Load reads all config files. It's called by the App before parsing.
+func (c *Config) Load() error {
+	if c.loaded {
+		return nil
+	}
+
+	values := make(map[string][]string)
+	files := make(map[string]string)
+	for _, file := range c.Files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return &ConfigError{
+				File: file,
+				Err:  err,
+			}
+		}
+
+		decoder, ok := c.decoder(filepath.Ext(file))
+		if !ok {
+			return &ConfigError{
+				File: file,
+				Err:  ErrUnknownFormat,
+			}
+		}
+
+		m, err := decoder.DecodeConfig(data)
+		if err != nil {
+			ce := &ConfigError{
+				File: file,
+				Err:  err,
+			}
+
+			// Decoders may report lines.
+			var le *ConfigError
+			if errors.As(err, &le) {
+				ce.Line = le.Line
+				ce.Err = le.Err
+			}
+
+			return ce
+		}
+
+		// Values of the next files override previous values.
+		fileValues := make(map[string][]string)
+		flattenConfig("", m, fileValues)
+
+		for key, v := range fileValues {
+			values[key] = v
+			files[key] = file
+		}
+	}
+
+	c.values = values
+	c.files = files
+	c.loaded = true
+
+	return nil
+}
+
+// Lookup returns values of the key in the section.
+func (c *Config) Lookup(section []string, key string) ([]string, bool) {
+	if len(section) > 0 {
+		key = strings.Join(section, ".") + "." + key
+	}
+
+	values, ok := c.values[key]
+	return values, ok
+}
+
+// File returns the file with the key in the section.
+func (c *Config) File(section []string, key string) string {
+	if len(section) > 0 {
+		key = strings.Join(section, ".") + "." + key
+	}
+
+	return c.files[key]
+}
+
+func (c *Config) decoder(ext string) (ConfigDecoder, bool) {
+	if c.Decoders != nil {
+		d, ok := c.Decoders[ext]
+		return d, ok
+	}
+
+	switch ext {
+	case ".json":
+		return JSONConfigDecoder, true
+
+	case ".ini", ".toml":
+		return INIConfigDecoder, true
+
+	default:
+		return nil, false
+	}
+}
+
+func flattenConfig(prefix string, m map[string]interface{}, values map[string][]string) {
+	for k, v := range m {
+		key := prefix + k
+
+		switch v := v.(type) {
+		case map[string]interface{}:
+			flattenConfig(key+".", v, values)
+
+		case []interface{}:
+			res := make([]string, 0, len(v))
+			for _, item := range v {
+				res = append(res, configValue(item))
+			}
+
+			values[key] = res
+
+		default:
+			values[key] = []string{configValue(v)}
+		}
+	}
+}
+
+func configValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+
+	case json.Number:
+		return v.String()
+
+	case nil:
+		return ""
+
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func decodeJSONConfig(data []byte) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var m map[string]interface{}
+	if err := dec.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func decodeINIConfig(data []byte) (map[string]interface{}, error) {
+	res := make(map[string]interface{})
+	section := res
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		s := strings.TrimSpace(scanner.Text())
+
+		// Skip empty lines and comments.
+		if s == "" || s[0] == '#' || s[0] == ';' {
+			continue
+		}
+
+		// Section: "[remote.add]" or "[remote add]".
+		if s[0] == '[' {
+			if s[len(s)-1] != ']' {
+				return nil, &ConfigError{Line: line, Err: ErrSyntax}
+			}
+
+			section = res
+			for _, name := range strings.FieldsFunc(s[1:len(s)-1], func(r rune) bool { return r == '.' || r == ' ' }) {
+				next, ok := section[name].(map[string]interface{})
+				if !ok {
+					next = make(map[string]interface{})
+					section[name] = next
+				}
+
+				section = next
+			}
+
+			continue
+		}
+
+		idx := strings.IndexByte(s, '=')
+		if idx < 1 {
+			return nil, &ConfigError{Line: line, Err: ErrSyntax}
+		}
+
+		key := strings.TrimSpace(s[:idx])
+		value, err := parseINIValue(strings.TrimSpace(s[idx+1:]))
+		if err != nil {
+			return nil, &ConfigError{Line: line, Err: err}
+		}
+
+		// Repeated keys are arrays.
+		if prev, ok := section[key]; ok {
+			if values, ok := prev.([]interface{}); ok {
+				value = append(values, value)
+			} else {
+				value = []interface{}{prev, value}
+			}
+		}
+
+		section[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// parseINIValue parses a raw value: plain or quoted strings and arrays of them
+// (["a", "b"]).
+func parseINIValue(s string) (interface{}, error) {
+	if s == "" {
+		return "", nil
+	}
+
+	if s[0] == '[' {
+		if s[len(s)-1] != ']' {
+			return nil, ErrSyntax
+		}
+
+		inner := strings.TrimSpace(s[1 : len(s)-1])
+		if inner == "" {
+			return []interface{}{}, nil
+		}
+
+		var values []interface{}
+		for inner != "" {
+			value, rest, err := nextINIValue(inner, true)
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, value)
+
+			rest = strings.TrimSpace(rest)
+			if rest != "" {
+				if rest[0] != ',' {
+					return nil, ErrSyntax
+				}
+
+				rest = strings.TrimSpace(rest[1:])
+			}
+
+			inner = rest
+		}
+
+		return values, nil
+	}
+
+	value, rest, err := nextINIValue(s, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Allow trailing comments.
+	if rest = strings.TrimSpace(rest); rest != "" && rest[0] != '#' && rest[0] != ';' {
+		return nil, ErrSyntax
+	}
+
+	return value, nil
+}
+
+// nextINIValue returns the first value of s. Plain values end at a comment
+// ("#" or ";" after a whitespace) or, in arrays, at a comma.
+func nextINIValue(s string, array bool) (value string, rest string, err error) {
+	if s[0] != '"' && s[0] != '\'' {
+		end := -1
+		for i := 0; i < len(s) && end == -1; i++ {
+			switch s[i] {
+			case ',':
+				if array {
+					end = i
+				}
+
+			case '#', ';':
+				if i > 0 && (s[i-1] == ' ' || s[i-1] == '\t') {
+					end = i
+				}
+			}
+		}
+
+		if end == -1 {
+			return strings.TrimSpace(s), "", nil
+		}
+
+		return strings.TrimSpace(s[:end]), s[end:], nil
+	}
+
+	// Single quoted values are literal. Double quoted values may contain
+	// escaped quotes.
+	quote := s[0]
+	end := -1
+	for i := 1; i < len(s) && end == -1; i++ {
+		switch s[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+
+		case quote:
+			end = i
+		}
+	}
+
+	if end == -1 {
+		return "", "", ErrUnterminatedQuote
+	}
+
+	value = s[1:end]
+	if quote == '"' {
+		value, err = strconv.Unquote(s[:end+1])
+		if err != nil {
+			return "", "", ErrSyntax
+		}
+	}
+
+	return value, s[end+1:], nil
+}
+
+// ConfigValues are values of a key from a config file.
+type ConfigValues struct {
+	Values []string
+	File   string // The config file.
+	Key    string // The full key: "remote.add.tags".
+}
+
+// ConfigLookuper is an optional interface of a Commander. The parser uses it to
+// read values of flags from config files. The depth is an index of a command
+// in the chain of commands (0 is the root command).
+type ConfigLookuper interface {
+	LookupConfig(depth int, key string) (ConfigValues, bool)
+}
+
+func setFlagsFromConfig(r, last Register, depth int, lookupConfig func(depth int, key string) (ConfigValues, bool), deprecated func(flag *Flag, values []string) error) error {
+	flags := r.Flags()
+	for i := range flags {
+		flag := &flags[i]
+
+		if flag.Set() || flag.Long == "" || flag.commandFlag {
+			continue
+		}
+
+		// The value is set via the inherited copy of the flag.
+		if r != last && flag.Global && inheritedFlag(last, flag) {
+			continue
+		}
+
+		// Inherited flags can be set in sections of parents.
+		var (
+			cv ConfigValues
+			ok bool
+		)
+		for d := depth; d >= 0 && !ok; d-- {
+			cv, ok = lookupConfig(d, flag.Long)
+			if !flag.inherited {
+				break
+			}
+		}
+
+		if !ok {
+			continue
+		}
+
+		values := cv.Values
+
+		// Read values from files: "@path".
+		if flag.FromFile {
+			files := values
+			values = make([]string, len(files))
+			for i, value := range files {
+				v, err := flagFileValue(value, false)
+				if err != nil {
+					return &FlagError{
+						Short:      flag.Short,
+						Long:       flag.Long,
+						ConfigFile: cv.File,
+						ConfigKey:  cv.Key,
+						Err:        err,
+					}
+				}
+
+				values[i] = v
+			}
+		}
+
+		for _, value := range values {
+			err := flag.Value.Set(value)
+			if err == nil {
+				err = validateValue(flag.Value, flag.Validators)
+			}
+
+			if err != nil {
+				return &FlagError{
+					Short:      flag.Short,
+					Long:       flag.Long,
+					ConfigFile: cv.File,
+					ConfigKey:  cv.Key,
+					Err:        flag.maskError(err),
+				}
+			}
+		}
+
+		flag.MarkSet()
+
+		if err := deprecated(flag, values); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
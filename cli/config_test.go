@@ -0,0 +1,469 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestINIConfigDecoder(t *testing.T) {
+	data := `
+# comment
+verbose = true
+name = "hello world" ; comment
+tag = a
+tag = b
+
+[remote add]
+fetch = yes
+hosts = ["a, b", 'c', d]
+`
+
+	got, err := INIConfigDecoder.DecodeConfig([]byte(data))
+	if err != nil {
+		t.Fatalf("DecodeConfig(): failed to decode: %s", err)
+	}
+
+	want := map[string]interface{}{
+		"verbose": "true",
+		"name":    "hello world",
+		"tag":     []interface{}{"a", "b"},
+		"remote": map[string]interface{}{
+			"add": map[string]interface{}{
+				"fetch": "yes",
+				"hosts": []interface{}{"a, b", "c", "d"},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DecodeConfig(): got = %#v, want = %#v", got, want)
+	}
+}
+
+func TestINIConfigDecoder_plain_values(t *testing.T) {
+	tt := []struct {
+		name string
+		data string
+		want interface{}
+	}{
+		{
+			name: "comma",
+			data: "a = x,y",
+			want: "x,y",
+		},
+		{
+			name: "hash",
+			data: "a = http://x/#frag",
+			want: "http://x/#frag",
+		},
+		{
+			name: "semicolon",
+			data: "a = ab;cd",
+			want: "ab;cd",
+		},
+		{
+			name: "hash comment",
+			data: "a = ab #cd",
+			want: "ab",
+		},
+		{
+			name: "semicolon comment",
+			data: "a = ab\t; cd",
+			want: "ab",
+		},
+		{
+			name: "array",
+			data: "a = [x,y, z#1]",
+			want: []interface{}{"x", "y", "z#1"},
+		},
+		{
+			name: "escaped quote",
+			data: `a = "x\"y" # comment`,
+			want: `x"y`,
+		},
+		{
+			name: "escaped backslash",
+			data: `a = "x\\"`,
+			want: `x\`,
+		},
+		{
+			name: "single quotes",
+			data: `a = 'x\'`,
+			want: `x\`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := INIConfigDecoder.DecodeConfig([]byte(tc.data))
+			if err != nil {
+				t.Fatalf("DecodeConfig(): failed to decode: %s", err)
+			}
+
+			if !reflect.DeepEqual(got["a"], tc.want) {
+				t.Errorf("DecodeConfig(): got = %#v, want = %#v", got["a"], tc.want)
+			}
+		})
+	}
+}
+
+func TestINIConfigDecoder_errors(t *testing.T) {
+	tt := []struct {
+		name string
+		data string
+		want error
+	}{
+		{
+			name: "unterminated section",
+			data: "a = 1\n[remote",
+			want: &ConfigError{Line: 2, Err: ErrSyntax},
+		},
+		{
+			name: "missing value",
+			data: "a",
+			want: &ConfigError{Line: 1, Err: ErrSyntax},
+		},
+		{
+			name: "unterminated quote",
+			data: "\na = 'b",
+			want: &ConfigError{Line: 2, Err: ErrUnterminatedQuote},
+		},
+		{
+			name: "unterminated escaped quote",
+			data: `a = "b\"`,
+			want: &ConfigError{Line: 1, Err: ErrUnterminatedQuote},
+		},
+		{
+			name: "invalid escape",
+			data: `a = "b\q"`,
+			want: &ConfigError{Line: 1, Err: ErrSyntax},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := INIConfigDecoder.DecodeConfig([]byte(tc.data))
+			if !errors.Is(err, tc.want) {
+				t.Errorf("DecodeConfig(): got error = %q, want error = %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestDefaultConfigFiles(t *testing.T) {
+	lookupEnv := func(key string) (string, bool) {
+		if key == "XDG_CONFIG_HOME" {
+			return "/xdg", true
+		}
+
+		return "", false
+	}
+
+	got := DefaultConfigFiles("app", ".ini", lookupEnv)
+
+	want := []string{
+		filepath.Join("/etc", "app", "config.ini"),
+		filepath.Join("/xdg", "app", "config.ini"),
+		".app.ini",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DefaultConfigFiles(): got = %#v, want = %#v", got, want)
+	}
+}
+
+func TestConfig_Load(t *testing.T) {
+	dir := writeTempFiles(t, map[string]string{
+		"system.json": `{"jobs": 2, "verbose": true, "remote": {"add": {"tags": ["a", "b"]}}}`,
+		"user.ini":    "jobs = 4\n[remote.add]\nfetch = true",
+	})
+	defer os.RemoveAll(dir)
+
+	config := Config{
+		Files: []string{
+			filepath.Join(dir, "system.json"),
+			filepath.Join(dir, "missing.json"),
+			filepath.Join(dir, "user.ini"),
+		},
+	}
+
+	if err := config.Load(); err != nil {
+		t.Fatalf("Load(): failed to load: %s", err)
+	}
+
+	tt := []struct {
+		section []string
+		key     string
+		want    []string
+		wantOk  bool
+	}{
+		{key: "jobs", want: []string{"4"}, wantOk: true},
+		{key: "verbose", want: []string{"true"}, wantOk: true},
+		{section: []string{"remote", "add"}, key: "tags", want: []string{"a", "b"}, wantOk: true},
+		{section: []string{"remote", "add"}, key: "fetch", want: []string{"true"}, wantOk: true},
+		{section: []string{"remote"}, key: "jobs"},
+		{key: "unknown"},
+	}
+
+	for _, tc := range tt {
+		got, ok := config.Lookup(tc.section, tc.key)
+		if ok != tc.wantOk {
+			t.Errorf("Lookup(%v, %q): ok: got = %v, want = %v", tc.section, tc.key, ok, tc.wantOk)
+		}
+
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Lookup(%v, %q): got = %#v, want = %#v", tc.section, tc.key, got, tc.want)
+		}
+	}
+}
+
+func TestConfig_Load_errors(t *testing.T) {
+	dir := writeTempFiles(t, map[string]string{
+		"broken.ini": "a = 1\nb",
+		"config.yml": "a: 1",
+	})
+	defer os.RemoveAll(dir)
+
+	tt := []struct {
+		name string
+		file string
+		want error
+	}{
+		{
+			name: "syntax",
+			file: filepath.Join(dir, "broken.ini"),
+			want: &ConfigError{File: filepath.Join(dir, "broken.ini"), Line: 2, Err: ErrSyntax},
+		},
+		{
+			name: "unknown format",
+			file: filepath.Join(dir, "config.yml"),
+			want: &ConfigError{File: filepath.Join(dir, "config.yml"), Err: ErrUnknownFormat},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			config := Config{Files: []string{tc.file}}
+
+			err := config.Load()
+			if !errors.Is(err, tc.want) {
+				t.Errorf("Load(): got error = %q, want error = %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestApp_Run_config(t *testing.T) {
+	dir := writeTempFiles(t, map[string]string{
+		"config.ini": `
+jobs = 2
+name = config
+verbose = true
+
+[sub]
+level = 3
+tag = ["a", "b"]
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	env := map[string]string{
+		"TEST_NAME": "env",
+	}
+
+	tt := []struct {
+		name        string
+		args        []string
+		wantJobs    int
+		wantName    string
+		wantVerbose bool
+		wantLevel   int
+		wantTags    []string
+	}{
+		{
+			name:        "from config",
+			args:        []string{},
+			wantJobs:    2,
+			wantName:    "env",
+			wantVerbose: true,
+		},
+		{
+			name:        "from command line",
+			args:        []string{"--jobs", "8", "--name", "cli"},
+			wantJobs:    8,
+			wantName:    "cli",
+			wantVerbose: true,
+		},
+		{
+			name:        "subcommand section",
+			args:        []string{"sub"},
+			wantJobs:    2,
+			wantName:    "env",
+			wantVerbose: true,
+			wantLevel:   3,
+			wantTags:    []string{"a", "b"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				jobs    int
+				name    string
+				verbose bool
+				level   int
+				tags    []string
+			)
+
+			app := App{
+				Name: "test",
+				Args: append([]string{}, tc.args...),
+				LookupEnv: func(key string) (string, bool) {
+					v, ok := env[key]
+					return v, ok
+				},
+				Config: &Config{
+					Files: []string{filepath.Join(dir, "config.ini")},
+				},
+				Action: ActionFunc(func(cmd *Command) ActionRunner {
+					_ = IntVar(cmd, &jobs, "jobs", Required)
+					_ = StringVar(cmd, &name, "name", WithEnv("TEST_NAME"))
+					_ = BoolVar(cmd, &verbose, "verbose", Global)
+
+					return func(cmd *Command) error { return nil }
+				}),
+				Commands: []Command{
+					{
+						Name: "sub",
+						Action: ActionFunc(func(cmd *Command) ActionRunner {
+							_ = IntVar(cmd, &level, "level")
+							_ = StringsVar(cmd, &tags, "tag")
+
+							return func(cmd *Command) error { return nil }
+						}),
+					},
+				},
+			}
+
+			if err := app.Run(); err != nil {
+				t.Fatalf("Run(): failed to run: %s", err)
+			}
+
+			if jobs != tc.wantJobs {
+				t.Errorf("Run(): jobs: got = %v, want = %v", jobs, tc.wantJobs)
+			}
+
+			if name != tc.wantName {
+				t.Errorf("Run(): name: got = %q, want = %q", name, tc.wantName)
+			}
+
+			if verbose != tc.wantVerbose {
+				t.Errorf("Run(): verbose: got = %v, want = %v", verbose, tc.wantVerbose)
+			}
+
+			if level != tc.wantLevel {
+				t.Errorf("Run(): level: got = %v, want = %v", level, tc.wantLevel)
+			}
+
+			if !reflect.DeepEqual(tags, tc.wantTags) {
+				t.Errorf("Run(): tags: got = %#v, want = %#v", tags, tc.wantTags)
+			}
+		})
+	}
+}
+
+func TestApp_Run_config_deprecated(t *testing.T) {
+	dir := writeTempFiles(t, map[string]string{
+		"config.ini": `label = ["a", "b"]`,
+	})
+	defer os.RemoveAll(dir)
+
+	tt := []struct {
+		name     string
+		args     []string
+		wantTags []string
+	}{
+		{
+			name:     "forward all values",
+			args:     []string{},
+			wantTags: []string{"a", "b"},
+		},
+		{
+			name:     "replacement is set",
+			args:     []string{"--tag", "c"},
+			wantTags: []string{"c"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				tags   []string
+				stderr strings.Builder
+			)
+
+			app := App{
+				Name:   "test",
+				Args:   append([]string{}, tc.args...),
+				Stderr: &stderr,
+				Config: &Config{
+					Files: []string{filepath.Join(dir, "config.ini")},
+				},
+				Action: ActionFunc(func(cmd *Command) ActionRunner {
+					_ = StringsVar(cmd, &tags, "tag")
+					_ = Strings(cmd, "label", Deprecation{Replacement: "tag"})
+
+					return func(cmd *Command) error { return nil }
+				}),
+			}
+
+			if err := app.Run(); err != nil {
+				t.Fatalf("Run(): failed to run: %s", err)
+			}
+
+			if !reflect.DeepEqual(tags, tc.wantTags) {
+				t.Errorf("Run(): tags: got = %#v, want = %#v", tags, tc.wantTags)
+			}
+		})
+	}
+}
+
+func TestApp_Run_config_invalid_value(t *testing.T) {
+	dir := writeTempFiles(t, map[string]string{
+		"config.json": `{"jobs": "many"}`,
+	})
+	defer os.RemoveAll(dir)
+
+	app := App{
+		Name: "test",
+		Args: []string{},
+		Config: &Config{
+			Files: []string{filepath.Join(dir, "config.json")},
+		},
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Int(cmd, "jobs")
+
+			return func(cmd *Command) error { return nil }
+		}),
+	}
+
+	err := app.Run()
+
+	want := &FlagError{Long: "jobs", Err: &ParseValueError{Type: "int", Err: ErrSyntax}}
+	if !errors.Is(err, want) {
+		t.Fatalf("Run(): got error = %q, want error = %q", err, want)
+	}
+
+	var flagErr *FlagError
+	if !errors.As(err, &flagErr) {
+		t.Fatalf("Run(): got error = %q, want a flag error", err)
+	}
+
+	if file := filepath.Join(dir, "config.json"); flagErr.ConfigFile != file || flagErr.ConfigKey != "jobs" {
+		t.Errorf("Run(): config: got = %q %q, want = %q %q", flagErr.ConfigFile, flagErr.ConfigKey, file, "jobs")
+	}
+}
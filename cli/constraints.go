@@ -0,0 +1,268 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrMutuallyExclusive = errors.New("mutually exclusive")
+
+	ErrRequiredTogether = errors.New("required together")
+
+	ErrRequiredUnless = errors.New("required unless")
+
+	ErrConstraintsNotSupported = errors.New("constraints are not supported")
+)
+
+type ConstraintKind int
+
+const (
+	MutuallyExclusiveConstraint ConstraintKind = iota
+	RequiredTogetherConstraint
+	RequiredUnlessConstraint
+)
+
+// Constraint is a rule for a group of flags. Flags are referenced by long or
+// short names (without dashes) and must be registered before the constraint.
+type Constraint struct {
+	Kind  ConstraintKind
+	Flags []string
+}
+
+type ConstraintError struct {
+	Flags []string // Formatted names of flags of the violated constraint.
+	Err   error
+}
+
+func (e *ConstraintError) Error() string {
+	msg := "unknown error"
+	if e.Err != nil {
+		msg = e.Err.Error()
+	}
+
+	if len(e.Flags) == 0 {
+		return fmt.Sprintf("cli: constraint error: %s", msg)
+	}
+
+	return fmt.Sprintf("cli: constraint error: '%s': %s", strings.Join(e.Flags, "' '"), msg)
+}
+
+func (e *ConstraintError) Unwrap() error { return e.Err }
+
+func (e *ConstraintError) Is(err error) bool {
+	ce, ok := err.(*ConstraintError)
+	if !ok || len(ce.Flags) != len(e.Flags) || !errors.Is(ce.Err, e.Err) {
+		return false
+	}
+
+	for i := range ce.Flags {
+		if ce.Flags[i] != e.Flags[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ConstraintRegister is an optional interface of a Register. The parser checks
+// constraints after parsing if the register implements it.
+type ConstraintRegister interface {
+	RegisterConstraint(c Constraint) error
+	Constraints() []Constraint
+}
+
+// MutuallyExclusive allows to set at most one of the flags.
+//
+//   _ = cli.MutuallyExclusive(cmd, "json", "yaml")
+func MutuallyExclusive(register Register, names ...string) error {
+	return registerConstraint(register, MutuallyExclusiveConstraint, names)
+}
+
+// RequiredTogether requires all of the flags if any of them is set.
+//
+//   _ = cli.RequiredTogether(cmd, "user", "password")
+func RequiredTogether(register Register, names ...string) error {
+	return registerConstraint(register, RequiredTogetherConstraint, names)
+}
+
+// RequiredUnless requires the flag unless any of the other flags is set.
+//
+//   _ = cli.RequiredUnless(cmd, "token", "anonymous")
+func RequiredUnless(register Register, name string, unless ...string) error {
+	return registerConstraint(register, RequiredUnlessConstraint, append([]string{name}, unless...))
+}
+
+func registerConstraint(register Register, kind ConstraintKind, names []string) error {
+	cr, ok := register.(ConstraintRegister)
+	if !ok {
+		return &ConstraintError{
+			Flags: names,
+			Err:   ErrConstraintsNotSupported,
+		}
+	}
+
+	return cr.RegisterConstraint(Constraint{
+		Kind:  kind,
+		Flags: names,
+	})
+}
+
+func (r *DefaultRegister) RegisterConstraint(c Constraint) (err error) {
+	defer func() {
+		if err != nil && r.registerConstraintErr == nil {
+			r.registerConstraintErr = err
+		}
+	}()
+
+	if len(c.Flags) < 2 {
+		return &ConstraintError{
+			Flags: c.Flags,
+			Err:   ErrMissingName,
+		}
+	}
+
+	for _, name := range c.Flags {
+		if name == "" {
+			return &ConstraintError{
+				Flags: c.Flags,
+				Err:   ErrMissingName,
+			}
+		}
+	}
+
+	// Flags must be registered before constraints.
+	for _, name := range c.Flags {
+		if _, ok := lookupFlag(r, name); !ok {
+			return &ConstraintError{
+				Flags: c.Flags,
+				Err:   ErrUnknown,
+			}
+		}
+	}
+
+	r.constraints = append(r.constraints, c)
+
+	return nil
+}
+
+func (r *DefaultRegister) Constraints() []Constraint {
+	return r.constraints
+}
+
+// checkConstraints checks constraints of all registers of the command chain.
+func (p *DefaultParser) checkConstraints(registers []Register) error {
+	for depth, r := range registers {
+		if err := p.checkRegisterConstraints(r, registers[depth+1:]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *DefaultParser) checkRegisterConstraints(r Register, children []Register) error {
+	cr, ok := r.(ConstraintRegister)
+	if !ok {
+		return nil
+	}
+
+	for _, c := range cr.Constraints() {
+		flags := make([]*Flag, len(c.Flags))
+		for i, name := range c.Flags {
+			flag, ok := lookupFlag(r, name)
+			if !ok {
+				return &FlagError{
+					Long: name,
+					Err:  ErrUnknown,
+				}
+			}
+
+			// Global flags set after a subcommand are set via inherited
+			// copies.
+			for _, child := range children {
+				if !flag.Global || !inheritedFlag(child, flag) {
+					break
+				}
+
+				flag, _ = lookupFlag(child, name)
+			}
+
+			flags[i] = flag
+		}
+
+		var all, set []string
+		for _, flag := range flags {
+			all = append(all, p.formatFlag(flag))
+
+			if flag.Set() {
+				set = append(set, p.formatFlag(flag))
+			}
+		}
+
+		switch c.Kind {
+		case MutuallyExclusiveConstraint:
+			if len(set) > 1 {
+				return &ConstraintError{
+					Flags: set,
+					Err:   ErrMutuallyExclusive,
+				}
+			}
+
+		case RequiredTogetherConstraint:
+			if len(set) > 0 && len(set) < len(all) {
+				return &ConstraintError{
+					Flags: all,
+					Err:   ErrRequiredTogether,
+				}
+			}
+
+		case RequiredUnlessConstraint:
+			if len(set) == 0 {
+				return &ConstraintError{
+					Flags: all,
+					Err:   ErrRequiredUnless,
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Command) RegisterConstraint(constraint Constraint) error {
+	cr, ok := c.register.(ConstraintRegister)
+	if !ok {
+		return &ConstraintError{
+			Flags: constraint.Flags,
+			Err:   ErrConstraintsNotSupported,
+		}
+	}
+
+	// Global flags of parents are inherited after the setup of the command,
+	// so inherit flags used in the constraint now.
+	if c.parent != nil {
+		for _, name := range constraint.Flags {
+			if _, ok := lookupFlag(c, name); ok || name == "" {
+				continue
+			}
+
+			if flag, ok := lookupFlag(c.parent, name); ok && flag.Global {
+				if err := c.inheritFlags([]Flag{*flag}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return cr.RegisterConstraint(constraint)
+}
+
+func (c *Command) Constraints() []Constraint {
+	if cr, ok := c.register.(ConstraintRegister); ok {
+		return cr.Constraints()
+	}
+
+	return nil
+}
@@ -0,0 +1,302 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParser_Parse_constraints(t *testing.T) {
+	tt := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{
+			name: "anonymous",
+			args: []string{"--anonymous"},
+		},
+		{
+			name: "token",
+			args: []string{"--token", "secret", "--json"},
+		},
+		{
+			name:    "mutually exclusive",
+			args:    []string{"--anonymous", "--json", "--yaml"},
+			wantErr: &ConstraintError{Flags: []string{"--json", "--yaml"}, Err: ErrMutuallyExclusive},
+		},
+		{
+			name: "required together",
+			args: []string{"--anonymous", "--user", "root", "-p", "toor"},
+		},
+		{
+			name:    "required together partially",
+			args:    []string{"--anonymous", "-p", "toor"},
+			wantErr: &ConstraintError{Flags: []string{"--user", "-p"}, Err: ErrRequiredTogether},
+		},
+		{
+			name:    "required unless",
+			args:    []string{"--json"},
+			wantErr: &ConstraintError{Flags: []string{"--token", "--anonymous"}, Err: ErrRequiredUnless},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			_ = Bool(&register, "json")
+			_ = Bool(&register, "yaml")
+			_ = String(&register, "user")
+			_ = String(&register, "p")
+			_ = String(&register, "token")
+			_ = Bool(&register, "anonymous")
+
+			_ = MutuallyExclusive(&register, "json", "yaml")
+			_ = RequiredTogether(&register, "user", "p")
+			_ = RequiredUnless(&register, "token", "anonymous")
+
+			err := parser.Parse(nil, &register, tc.args)
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Parse(): failed to parse args: %s", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Parse(): got error = %q, want error = %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestParser_Parse_constraints_subcommands(t *testing.T) {
+	tt := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{
+			name: "one flag",
+			args: []string{"--json", "sub"},
+		},
+		{
+			name:    "before subcommand",
+			args:    []string{"--json", "--yaml", "sub"},
+			wantErr: &ConstraintError{Flags: []string{"--json", "--yaml"}, Err: ErrMutuallyExclusive},
+		},
+		{
+			name:    "global after subcommand",
+			args:    []string{"--json", "sub", "--yaml"},
+			wantErr: &ConstraintError{Flags: []string{"--json", "--yaml"}, Err: ErrMutuallyExclusive},
+		},
+		{
+			name:    "leaf",
+			args:    []string{"sub", "--user", "root"},
+			wantErr: &ConstraintError{Flags: []string{"--user", "--yaml"}, Err: ErrRequiredTogether},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			app := App{
+				Name: "test",
+				Args: append([]string{}, tc.args...),
+				Action: ActionFunc(func(cmd *Command) ActionRunner {
+					_ = Bool(cmd, "json")
+					_ = Bool(cmd, "yaml", Global)
+
+					_ = MutuallyExclusive(cmd, "json", "yaml")
+
+					return func(cmd *Command) error { return nil }
+				}),
+				Commands: []Command{
+					{
+						Name: "sub",
+						Action: ActionFunc(func(cmd *Command) ActionRunner {
+							_ = String(cmd, "user")
+
+							_ = RequiredTogether(cmd, "user", "yaml")
+
+							return func(cmd *Command) error { return nil }
+						}),
+					},
+				},
+			}
+
+			err := app.Run()
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Run(): failed to run: %s", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Run(): got error = %q, want error = %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegisterConstraint(t *testing.T) {
+	tt := []struct {
+		name  string
+		flags []string
+		want  error
+	}{
+		{
+			name:  "one flag",
+			flags: []string{"json"},
+			want:  &ConstraintError{Flags: []string{"json"}, Err: ErrMissingName},
+		},
+		{
+			name:  "unknown flag",
+			flags: []string{"json", "yaml"},
+			want:  &ConstraintError{Flags: []string{"json", "yaml"}, Err: ErrUnknown},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var register DefaultRegister
+
+			_ = Bool(&register, "json")
+
+			got := MutuallyExclusive(&register, tc.flags...)
+			if !errors.Is(got, tc.want) {
+				t.Fatalf("RegisterConstraint(): got error = %q, want error = %q", got, tc.want)
+			}
+
+			if err := register.Err(); !errors.Is(err, tc.want) {
+				t.Fatalf("Err(): got error = %q, want error = %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestApp_handleError_constraints(t *testing.T) {
+	tt := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "mutually exclusive",
+			err:  &ConstraintError{Flags: []string{"--json", "--yaml", "--toml"}, Err: ErrMutuallyExclusive},
+			want: "Flags --json, --yaml and --toml cannot be used together\n",
+		},
+		{
+			name: "required together",
+			err:  &ConstraintError{Flags: []string{"--user", "--password"}, Err: ErrRequiredTogether},
+			want: "Flags --user and --password must be used together\n",
+		},
+		{
+			name: "required unless",
+			err:  &ConstraintError{Flags: []string{"--token", "--anonymous", "--guest"}, Err: ErrRequiredUnless},
+			want: "Flag --token is required unless --anonymous or --guest is set\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				app App
+				buf strings.Builder
+			)
+
+			_ = app.handleError(tc.err, &buf)
+
+			assertStringsDiff(t, buf.String(), tc.want)
+		})
+	}
+}
+
+func TestDefaultHelper_Help_constraints(t *testing.T) {
+	app := App{
+		Name: "constraints",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Bool(cmd, "json")
+			_ = Bool(cmd, "yaml")
+			_ = String(cmd, "token")
+			_ = Bool(cmd, "anonymous", WithShort("a"))
+			_ = Bool(cmd, "toml", Hidden)
+
+			_ = MutuallyExclusive(cmd, "json", "yaml", "toml")
+			_ = MutuallyExclusive(cmd, "json", "toml")
+			_ = RequiredTogether(cmd, "json", "token")
+			_ = RequiredUnless(cmd, "token", "a", "toml")
+			_ = RequiredUnless(cmd, "toml", "a")
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+	}
+
+	cmd, err := app.Command("constraints")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := (DefaultHelper{}).Help(cmd, &buf); err != nil {
+		t.Fatalf("Help(): failed to write help: %s", err)
+	}
+
+	want := `Usage: constraints [options...]
+
+Options:
+      --json
+      --yaml
+      --token string
+  -a, --anonymous
+
+Constraints:
+  --json, --yaml: mutually exclusive
+  --json, --token: required together
+  --token: required unless -a
+`
+
+	assertStringsDiff(t, buf.String(), want)
+}
+
+func TestDefaultHelper_Help_constraints_show_hidden(t *testing.T) {
+	app := App{
+		Name: "constraints",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Bool(cmd, "json")
+			_ = Bool(cmd, "toml", Hidden)
+
+			_ = MutuallyExclusive(cmd, "json", "toml")
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+	}
+
+	cmd, err := app.Command("constraints")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := (DefaultHelper{ShowHidden: true}).Help(cmd, &buf); err != nil {
+		t.Fatalf("Help(): failed to write help: %s", err)
+	}
+
+	want := `Usage: constraints [options...]
+
+Options:
+  --json
+  --toml
+
+Constraints:
+  --json, --toml: mutually exclusive
+`
+
+	assertStringsDiff(t, buf.String(), want)
+}
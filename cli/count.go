@@ -0,0 +1,73 @@
+package cli
+
+import "strconv"
+
+var (
+	_ Value     = (*countValue)(nil)
+	_ Getter    = (*countValue)(nil)
+	_ Emptier   = (*countValue)(nil)
+	_ Typer     = (*countValue)(nil)
+	_ countFlag = (*countValue)(nil)
+)
+
+type countValue int
+
+func newCountValue(p *int) *countValue {
+	return (*countValue)(p)
+}
+
+func (c *countValue) Set(s string) error {
+	v, err := strconv.ParseInt(s, 0, strconv.IntSize)
+	if err == nil && v < 0 {
+		err = &ParseValueError{
+			Type: "count",
+			Err:  ErrRange,
+		}
+	} else if err != nil {
+		err = numError("count", err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*c = countValue(v)
+	return nil
+}
+
+func (c *countValue) Increment() { *c++ }
+
+func (c *countValue) IsCountFlag() bool { return true }
+
+func (c *countValue) Get() interface{} { return int(*c) }
+
+func (c *countValue) Empty() bool { return *c == 0 }
+
+func (c *countValue) String() string { return strconv.Itoa(int(*c)) }
+
+func (*countValue) Type() string { return "count" }
+
+// countFlag is incremented on every occurrence without a value (-vvv).
+type countFlag interface {
+	Value
+	Increment()
+	IsCountFlag() bool
+}
+
+// CountVar defines a counter flag with specified name. Every occurrence of the
+// flag without a value increments the counter (-vvv is 3) and an explicit
+// value sets it (--verbose=3).
+func CountVar(register Register, p *int, name string, options ...FlagOptionApplyer) error {
+	return Var(register, newCountValue(p), name, options...)
+}
+
+// Count defines a counter flag with specified name.
+// The return value is the address of an int variable that stores the value of
+// the flag.
+//
+//   verbose := cli.Count(register, "verbose", cli.WithShort("v"))
+func Count(register Register, name string, options ...FlagOptionApplyer) *int {
+	p := new(int)
+	_ = CountVar(register, p, name, options...)
+	return p
+}
@@ -0,0 +1,597 @@
+package cli
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrIsDir = errors.New("is a directory")
+
+	ErrNotDir = errors.New("not a directory")
+
+	ErrFileClosed = errors.New("file already closed")
+)
+
+// stdioName is the name of files which use the standard input or output.
+const stdioName = "-"
+
+// fileFlag is completed as a file path.
+type fileFlag interface {
+	Value
+	IsFileFlag() bool
+}
+
+func isFileFlag(v Value) bool {
+	ff, ok := v.(fileFlag)
+	return ok && ff.IsFileFlag()
+}
+
+// attachFile binds the file to the command of the register (if any): "-"
+// becomes stdio of the command and the file is closed after the action is run.
+func attachFile(register Register, file io.Closer) *Command {
+	cmd, ok := register.(*Command)
+	if !ok {
+		return nil
+	}
+
+	cmd.onClose(file)
+	return cmd
+}
+
+// Input file.
+
+var _ io.ReadCloser = (*InputFile)(nil)
+
+// InputFile is a file opened for reading on the first Read. The "-" name is
+// the standard input of the command.
+//
+// Files registered in a Command are closed after the action is run.
+type InputFile struct {
+	Name string
+
+	cmd    *Command
+	r      io.Reader
+	file   *os.File
+	err    error
+	closed bool
+}
+
+// IsStdin reports whether the file is the standard input.
+func (f *InputFile) IsStdin() bool { return f.Name == stdioName }
+
+// Open opens the file if it is not opened yet.
+func (f *InputFile) Open() error {
+	if f.closed {
+		return ErrFileClosed
+	}
+
+	if f.r != nil || f.err != nil {
+		return f.err
+	}
+
+	if f.IsStdin() {
+		f.r = os.Stdin
+		if f.cmd != nil {
+			f.r = f.cmd.Stdin()
+		}
+
+		return nil
+	}
+
+	f.file, f.err = os.Open(f.Name)
+	if f.err == nil {
+		f.r = f.file
+	}
+
+	return f.err
+}
+
+func (f *InputFile) Read(p []byte) (n int, err error) {
+	if err := f.Open(); err != nil {
+		return 0, err
+	}
+
+	return f.r.Read(p)
+}
+
+// Close closes the file. It does not close the standard input.
+func (f *InputFile) Close() error {
+	if f.closed {
+		return nil
+	}
+
+	f.closed = true
+
+	if f.file != nil {
+		return f.file.Close()
+	}
+
+	return nil
+}
+
+func (f *InputFile) String() string { return f.Name }
+
+// checkInputFile checks that the file exists, is not a directory and can be
+// read.
+func checkInputFile(name string) error {
+	if name == stdioName {
+		return nil
+	}
+
+	fi, err := os.Stat(name)
+	if err != nil {
+		return &ParseValueError{
+			Type: "file",
+			Err:  err,
+		}
+	}
+
+	if fi.IsDir() {
+		return &ParseValueError{
+			Type: "file",
+			Err:  &os.PathError{Op: "open", Path: name, Err: ErrIsDir},
+		}
+	}
+
+	// The file is opened only in the action.
+	f, err := os.Open(name)
+	if err != nil {
+		return &ParseValueError{
+			Type: "file",
+			Err:  err,
+		}
+	}
+
+	_ = f.Close()
+
+	return nil
+}
+
+var (
+	_ Value    = (*inputFileValue)(nil)
+	_ Getter   = (*inputFileValue)(nil)
+	_ Emptier  = (*inputFileValue)(nil)
+	_ Typer    = (*inputFileValue)(nil)
+	_ fileFlag = (*inputFileValue)(nil)
+)
+
+type inputFileValue struct {
+	p *InputFile
+}
+
+func newInputFileValue(p *InputFile) *inputFileValue {
+	return &inputFileValue{p: p}
+}
+
+func (v *inputFileValue) Set(val string) error {
+	if err := checkInputFile(val); err != nil {
+		return err
+	}
+
+	v.p.Name = val
+	return nil
+}
+
+func (v *inputFileValue) Get() interface{} { return v.p }
+
+func (v *inputFileValue) Empty() bool { return v.p.Name == "" }
+
+func (v *inputFileValue) String() string { return v.p.Name }
+
+func (*inputFileValue) Type() string { return "file" }
+
+func (*inputFileValue) IsFileFlag() bool { return true }
+
+var (
+	_ Value      = (*inputFileValues)(nil)
+	_ Getter     = (*inputFileValues)(nil)
+	_ Emptier    = (*inputFileValues)(nil)
+	_ Typer      = (*inputFileValues)(nil)
+	_ fileFlag   = (*inputFileValues)(nil)
+	_ sliceValue = (*inputFileValues)(nil)
+)
+
+type inputFileValues struct {
+	p        *[]*InputFile
+	register Register
+}
+
+func newInputFileValues(p *[]*InputFile, register Register) *inputFileValues {
+	return &inputFileValues{p: p, register: register}
+}
+
+func (vs *inputFileValues) Set(val string) error {
+	if err := checkInputFile(val); err != nil {
+		return err
+	}
+
+	f := &InputFile{Name: val}
+	f.cmd = attachFile(vs.register, f)
+
+	*vs.p = append(*vs.p, f)
+	return nil
+}
+
+func (vs *inputFileValues) Get() interface{} { return *vs.p }
+
+func (vs *inputFileValues) Empty() bool { return len(*vs.p) == 0 }
+
+func (vs *inputFileValues) String() string {
+	names := make([]string, len(*vs.p))
+	for i, f := range *vs.p {
+		names[i] = f.Name
+	}
+
+	return strings.Join(names, ",")
+}
+
+func (*inputFileValues) Type() string { return "[]file" }
+
+func (*inputFileValues) IsSliceValue() bool { return true }
+
+func (*inputFileValues) IsFileFlag() bool { return true }
+
+// InputVar defines an input file flag with specified name. The file must
+// exist; "-" is the standard input.
+func InputVar(register Register, p *InputFile, name string, options ...FlagOptionApplyer) error {
+	p.cmd = attachFile(register, p)
+	return Var(register, newInputFileValue(p), name, options...)
+}
+
+// Input defines an input file flag with specified name. The file must exist;
+// "-" is the standard input.
+// The return value is the address of an InputFile variable. The file is opened
+// on the first Read.
+//
+//   in := cli.Input(register, "input", cli.WithShort("i"))
+func Input(register Register, name string, options ...FlagOptionApplyer) *InputFile {
+	p := new(InputFile)
+	_ = InputVar(register, p, name, options...)
+	return p
+}
+
+// InputArgVar defines an input file argument with specified name. The file
+// must exist; "-" is the standard input.
+func InputArgVar(register Register, p *InputFile, name string, options ...ArgOptionApplyer) error {
+	p.cmd = attachFile(register, p)
+	return ArgVar(register, newInputFileValue(p), name, options...)
+}
+
+// InputArg defines an input file argument with specified name. The file must
+// exist; "-" is the standard input.
+// The return value is the address of an InputFile variable. The file is opened
+// on the first Read.
+func InputArg(register Register, name string, options ...ArgOptionApplyer) *InputFile {
+	p := new(InputFile)
+	_ = InputArgVar(register, p, name, options...)
+	return p
+}
+
+// RestInputsVar defines the input file rest arguments with specified name.
+// Files must exist; "-" is the standard input.
+func RestInputsVar(register Register, p *[]*InputFile, name string, options ...RestOptionApplyer) error {
+	return RestVar(register, newInputFileValues(p, register), name, options...)
+}
+
+// RestInputs defines the input file rest arguments with specified name. Files
+// must exist; "-" is the standard input.
+// The return value is the address of a []*InputFile variable. Files are opened
+// on the first Read.
+//
+//   files := cli.RestInputs(register, "files")
+func RestInputs(register Register, name string, options ...RestOptionApplyer) *[]*InputFile {
+	p := new([]*InputFile)
+	_ = RestInputsVar(register, p, name, options...)
+	return p
+}
+
+// Output file.
+
+// OutputMode is a way to open an output file.
+type OutputMode uint8
+
+const (
+	// OutputCreate creates the file or truncates an existing one.
+	OutputCreate OutputMode = iota
+
+	// OutputAppend creates the file or appends to an existing one.
+	OutputAppend
+
+	// OutputAtomic writes into a temporary file in the same directory and
+	// replaces the file with it on Close. Discard removes the temporary file
+	// and keeps the original file untouched.
+	OutputAtomic
+)
+
+var (
+	_ io.WriteCloser = (*OutputFile)(nil)
+	_ discarder      = (*OutputFile)(nil)
+)
+
+// OutputFile is a file opened for writing on the first Write. The "-" name is
+// the standard output of the command.
+//
+// Files registered in a Command are closed after the action is run. If the
+// action fails, atomic files are discarded.
+type OutputFile struct {
+	Name string
+	Mode OutputMode
+	Perm os.FileMode // Permissions of a new file. The default is 0666.
+
+	cmd    *Command
+	w      io.Writer
+	file   *os.File
+	err    error
+	closed bool
+}
+
+// IsStdout reports whether the file is the standard output.
+func (f *OutputFile) IsStdout() bool { return f.Name == stdioName }
+
+func (f *OutputFile) perm() os.FileMode {
+	if f.Perm == 0 {
+		return 0666
+	}
+
+	return f.Perm
+}
+
+// Open opens the file if it is not opened yet.
+func (f *OutputFile) Open() error {
+	if f.closed {
+		return ErrFileClosed
+	}
+
+	if f.w != nil || f.err != nil {
+		return f.err
+	}
+
+	if f.IsStdout() {
+		f.w = os.Stdout
+		if f.cmd != nil {
+			f.w = f.cmd.Stdout()
+		}
+
+		return nil
+	}
+
+	switch f.Mode {
+	case OutputAppend:
+		f.file, f.err = os.OpenFile(f.Name, os.O_WRONLY|os.O_CREATE|os.O_APPEND, f.perm())
+
+	case OutputAtomic:
+		dir, base := filepath.Split(f.Name)
+		if dir == "" {
+			dir = "."
+		}
+
+		f.file, f.err = ioutil.TempFile(dir, "."+base+".tmp")
+		if f.err == nil {
+			perm := f.perm()
+			if fi, err := os.Stat(f.Name); err == nil {
+				perm = fi.Mode().Perm()
+			}
+
+			if err := f.file.Chmod(perm); err != nil {
+				_ = f.file.Close()
+				_ = os.Remove(f.file.Name())
+				f.file, f.err = nil, err
+			}
+		}
+
+	default:
+		f.file, f.err = os.OpenFile(f.Name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.perm())
+	}
+
+	if f.err == nil {
+		f.w = f.file
+	}
+
+	return f.err
+}
+
+func (f *OutputFile) Write(p []byte) (n int, err error) {
+	if err := f.Open(); err != nil {
+		return 0, err
+	}
+
+	return f.w.Write(p)
+}
+
+// Close closes the file. An atomic file replaces the original one. It does not
+// close the standard output.
+//
+// A file which has not been written is still truncated (or replaced with an
+// empty atomic file), so no stale content is left.
+func (f *OutputFile) Close() error {
+	if f.closed {
+		return nil
+	}
+
+	if f.w == nil && f.err == nil && f.Name != "" && f.Mode != OutputAppend {
+		if err := f.Open(); err != nil {
+			f.closed = true
+			return err
+		}
+	}
+
+	f.closed = true
+
+	if f.file == nil {
+		return nil
+	}
+
+	if f.Mode != OutputAtomic {
+		return f.file.Close()
+	}
+
+	tmp := f.file.Name()
+	if err := f.file.Sync(); err != nil {
+		_ = f.file.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	if err := f.file.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	if err := os.Rename(tmp, f.Name); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	return nil
+}
+
+// Discard closes the file. Unlike Close, an atomic file is removed and the
+// original file stays untouched. Other files are just closed.
+func (f *OutputFile) Discard() error {
+	if f.closed {
+		return nil
+	}
+
+	// Files which have not been written are left untouched.
+	if f.file == nil {
+		f.closed = true
+		return nil
+	}
+
+	if f.Mode != OutputAtomic {
+		return f.Close()
+	}
+
+	f.closed = true
+
+	tmp := f.file.Name()
+	err := f.file.Close()
+	if rerr := os.Remove(tmp); err == nil {
+		err = rerr
+	}
+
+	return err
+}
+
+func (f *OutputFile) String() string { return f.Name }
+
+// checkOutputFile checks that the file is not a directory, is writable, and
+// its directory exists.
+func checkOutputFile(name string) error {
+	if name == stdioName {
+		return nil
+	}
+
+	fi, err := os.Stat(name)
+	if err == nil {
+		if fi.IsDir() {
+			err = &os.PathError{Op: "open", Path: name, Err: ErrIsDir}
+		} else {
+			// The file is opened without truncation only to check access.
+			var f *os.File
+			if f, err = os.OpenFile(name, os.O_WRONLY, 0); err == nil {
+				_ = f.Close()
+			}
+		}
+	} else {
+		// A new file: its directory must exist.
+		dir := filepath.Dir(name)
+		if di, derr := os.Stat(dir); derr != nil {
+			err = derr
+		} else if !di.IsDir() {
+			err = &os.PathError{Op: "open", Path: dir, Err: ErrNotDir}
+		} else if os.IsNotExist(err) {
+			err = nil
+		}
+	}
+
+	if err != nil {
+		return &ParseValueError{
+			Type: "file",
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+var (
+	_ Value    = (*outputFileValue)(nil)
+	_ Getter   = (*outputFileValue)(nil)
+	_ Emptier  = (*outputFileValue)(nil)
+	_ Typer    = (*outputFileValue)(nil)
+	_ fileFlag = (*outputFileValue)(nil)
+)
+
+type outputFileValue struct {
+	p *OutputFile
+}
+
+func newOutputFileValue(p *OutputFile) *outputFileValue {
+	return &outputFileValue{p: p}
+}
+
+func (v *outputFileValue) Set(val string) error {
+	if err := checkOutputFile(val); err != nil {
+		return err
+	}
+
+	v.p.Name = val
+	return nil
+}
+
+func (v *outputFileValue) Get() interface{} { return v.p }
+
+func (v *outputFileValue) Empty() bool { return v.p.Name == "" }
+
+func (v *outputFileValue) String() string { return v.p.Name }
+
+func (*outputFileValue) Type() string { return "file" }
+
+func (*outputFileValue) IsFileFlag() bool { return true }
+
+// OutputVar defines an output file flag with specified name and mode. The
+// directory of the file must exist; "-" is the standard output.
+//
+// If the action succeeds, a file in the OutputCreate or OutputAtomic mode is
+// created or truncated even if the action has not written to it.
+func OutputVar(register Register, p *OutputFile, name string, mode OutputMode, options ...FlagOptionApplyer) error {
+	p.Mode = mode
+	p.cmd = attachFile(register, p)
+	return Var(register, newOutputFileValue(p), name, options...)
+}
+
+// Output defines an output file flag with specified name and mode. The
+// directory of the file must exist; "-" is the standard output.
+// The return value is the address of an OutputFile variable. The file is
+// opened on the first Write.
+//
+//   out := cli.Output(register, "output", cli.OutputAtomic, cli.WithShort("o"))
+func Output(register Register, name string, mode OutputMode, options ...FlagOptionApplyer) *OutputFile {
+	p := new(OutputFile)
+	_ = OutputVar(register, p, name, mode, options...)
+	return p
+}
+
+// OutputArgVar defines an output file argument with specified name and mode.
+// The directory of the file must exist; "-" is the standard output.
+func OutputArgVar(register Register, p *OutputFile, name string, mode OutputMode, options ...ArgOptionApplyer) error {
+	p.Mode = mode
+	p.cmd = attachFile(register, p)
+	return ArgVar(register, newOutputFileValue(p), name, options...)
+}
+
+// OutputArg defines an output file argument with specified name and mode. The
+// directory of the file must exist; "-" is the standard output.
+// The return value is the address of an OutputFile variable. The file is
+// opened on the first Write.
+func OutputArg(register Register, name string, mode OutputMode, options ...ArgOptionApplyer) *OutputFile {
+	p := new(OutputFile)
+	_ = OutputArgVar(register, p, name, mode, options...)
+	return p
+}
@@ -0,0 +1,489 @@
+package cli
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParser_Parse_files_errors(t *testing.T) {
+	dir := writeTempFiles(t, map[string]string{
+		"in.txt": "hello",
+	})
+	defer os.RemoveAll(dir)
+
+	tt := []struct {
+		name string
+		args []string
+		want error
+	}{
+		{
+			name: "missing input",
+			args: []string{"--input", filepath.Join(dir, "missing.txt")},
+			want: &FlagError{Long: "input", Err: &ParseValueError{Type: "file", Err: os.ErrNotExist}},
+		},
+		{
+			name: "input directory",
+			args: []string{"--input", dir},
+			want: &FlagError{Long: "input", Err: &ParseValueError{Type: "file", Err: ErrIsDir}},
+		},
+		{
+			name: "missing output directory",
+			args: []string{"--output", filepath.Join(dir, "missing", "out.txt")},
+			want: &FlagError{Long: "output", Err: &ParseValueError{Type: "file", Err: os.ErrNotExist}},
+		},
+		{
+			name: "output directory",
+			args: []string{"--output", dir},
+			want: &FlagError{Long: "output", Err: &ParseValueError{Type: "file", Err: ErrIsDir}},
+		},
+		{
+			name: "output in file",
+			args: []string{"--output", filepath.Join(dir, "in.txt", "out.txt")},
+			want: &FlagError{Long: "output", Err: &ParseValueError{Type: "file", Err: ErrNotDir}},
+		},
+		{
+			name: "missing rest",
+			args: []string{filepath.Join(dir, "in.txt"), filepath.Join(dir, "missing.txt")},
+			want: &ArgError{Name: "files", Index: 1, Err: &ParseValueError{Type: "file", Err: os.ErrNotExist}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			_ = Input(&register, "input")
+			_ = Output(&register, "output", OutputCreate)
+			_ = RestInputs(&register, "files")
+
+			if err := parser.Parse(nil, &register, tc.args); !errors.Is(err, tc.want) {
+				t.Errorf("Parse(): got error = %q, want error = %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestParser_Parse_files_permission(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root can access any file")
+	}
+
+	dir := writeTempFiles(t, map[string]string{
+		"secret.txt":   "hello",
+		"readonly.txt": "hello",
+	})
+	defer os.RemoveAll(dir)
+
+	modes := map[string]os.FileMode{
+		"secret.txt":   0200,
+		"readonly.txt": 0400,
+	}
+	for name, mode := range modes {
+		if err := os.Chmod(filepath.Join(dir, name), mode); err != nil {
+			t.Fatalf("Chmod(): failed to change mode: %s", err)
+		}
+	}
+
+	tt := []struct {
+		name string
+		args []string
+		want error
+	}{
+		{
+			name: "input",
+			args: []string{"--input", filepath.Join(dir, "secret.txt")},
+			want: &FlagError{Long: "input", Err: &ParseValueError{Type: "file", Err: os.ErrPermission}},
+		},
+		{
+			name: "output",
+			args: []string{"--output", filepath.Join(dir, "readonly.txt")},
+			want: &FlagError{Long: "output", Err: &ParseValueError{Type: "file", Err: os.ErrPermission}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			_ = Input(&register, "input")
+			_ = Output(&register, "output", OutputCreate)
+
+			if err := parser.Parse(nil, &register, tc.args); !errors.Is(err, tc.want) {
+				t.Errorf("Parse(): got error = %q, want error = %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestApp_Run_files(t *testing.T) {
+	dir := writeTempFiles(t, map[string]string{
+		"a.txt":   "a\n",
+		"b.txt":   "b\n",
+		"log.txt": "1\n",
+	})
+	defer os.RemoveAll(dir)
+
+	tt := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantStdout string
+		wantFiles  map[string]string
+	}{
+		{
+			name:      "create",
+			args:      []string{"-o", filepath.Join(dir, "out.txt"), filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt")},
+			wantFiles: map[string]string{"out.txt": "a\nb\n"},
+		},
+		{
+			name:      "append",
+			args:      []string{"--log", filepath.Join(dir, "log.txt"), filepath.Join(dir, "a.txt")},
+			wantFiles: map[string]string{"log.txt": "1\n2\n"},
+		},
+		{
+			name:       "stdio",
+			args:       []string{"-o", "-", "-"},
+			stdin:      "from stdin\n",
+			wantStdout: "from stdin\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				stdout strings.Builder
+				inputs *[]*InputFile
+			)
+
+			app := App{
+				Name:   "cat",
+				Args:   append([]string{}, tc.args...),
+				Stdin:  strings.NewReader(tc.stdin),
+				Stdout: &stdout,
+				Action: ActionFunc(func(cmd *Command) ActionRunner {
+					out := Output(cmd, "o", OutputCreate)
+					log := Output(cmd, "log", OutputAppend)
+					inputs = RestInputs(cmd, "files")
+
+					return func(cmd *Command) error {
+						if log.Name != "" {
+							if _, err := io.WriteString(log, "2\n"); err != nil {
+								return err
+							}
+						}
+
+						if out.Name == "" {
+							return nil
+						}
+
+						for _, in := range *inputs {
+							if _, err := io.Copy(out, in); err != nil {
+								return err
+							}
+						}
+
+						return nil
+					}
+				}),
+			}
+
+			if err := app.Run(); err != nil {
+				t.Fatalf("Run(): failed to run: %s", err)
+			}
+
+			if got := stdout.String(); got != tc.wantStdout {
+				t.Errorf("Run(): stdout: got = %q, want = %q", got, tc.wantStdout)
+			}
+
+			for name, want := range tc.wantFiles {
+				data, err := ioutil.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatalf("ReadFile(): failed to read %s: %s", name, err)
+				}
+
+				if got := string(data); got != want {
+					t.Errorf("Run(): %s: got = %q, want = %q", name, got, want)
+				}
+			}
+
+			for _, in := range *inputs {
+				if _, err := in.Read(make([]byte, 1)); err != ErrFileClosed {
+					t.Errorf("Read(): %s: got error = %v, want error = %v", in.Name, err, ErrFileClosed)
+				}
+			}
+		})
+	}
+}
+
+func TestApp_Run_files_atomic(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tt := []struct {
+		name    string
+		err     error
+		wantErr error
+		want    string
+	}{
+		{
+			name: "replace",
+			want: "new",
+		},
+		{
+			name:    "discard",
+			err:     errFailed,
+			wantErr: errFailed,
+			want:    "old",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writeTempFiles(t, map[string]string{
+				"out.txt": "old",
+			})
+			defer os.RemoveAll(dir)
+			name := filepath.Join(dir, "out.txt")
+
+			app := App{
+				Name: "write",
+				Args: []string{name},
+				Action: ActionFunc(func(cmd *Command) ActionRunner {
+					out := OutputArg(cmd, "output", OutputAtomic)
+
+					return func(cmd *Command) error {
+						if _, err := io.WriteString(out, "new"); err != nil {
+							return err
+						}
+
+						// Not replaced until the action returns.
+						if data, _ := ioutil.ReadFile(name); string(data) != "old" {
+							t.Errorf("Write(): got = %q, want = %q", data, "old")
+						}
+
+						return tc.err
+					}
+				}),
+			}
+
+			if err := app.Run(); err != tc.wantErr {
+				t.Fatalf("Run(): got error = %v, want error = %v", err, tc.wantErr)
+			}
+
+			data, err := ioutil.ReadFile(name)
+			if err != nil {
+				t.Fatalf("ReadFile(): failed to read: %s", err)
+			}
+
+			if got := string(data); got != tc.want {
+				t.Errorf("Run(): got = %q, want = %q", got, tc.want)
+			}
+
+			files, err := ioutil.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("ReadDir(): failed to read: %s", err)
+			}
+
+			if len(files) != 1 {
+				t.Errorf("Run(): temporary files are left: got %d files, want 1", len(files))
+			}
+		})
+	}
+}
+
+func TestApp_Run_files_empty(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tt := []struct {
+		name    string
+		mode    OutputMode
+		err     error
+		wantErr error
+		want    string
+	}{
+		{
+			name: "create",
+			mode: OutputCreate,
+			want: "",
+		},
+		{
+			name: "atomic",
+			mode: OutputAtomic,
+			want: "",
+		},
+		{
+			name:    "create discard",
+			mode:    OutputCreate,
+			err:     errFailed,
+			wantErr: errFailed,
+			want:    "stale",
+		},
+		{
+			name:    "atomic discard",
+			mode:    OutputAtomic,
+			err:     errFailed,
+			wantErr: errFailed,
+			want:    "stale",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writeTempFiles(t, map[string]string{
+				"out.txt": "stale",
+			})
+			defer os.RemoveAll(dir)
+			name := filepath.Join(dir, "out.txt")
+
+			app := App{
+				Name: "write",
+				Args: []string{name},
+				Action: ActionFunc(func(cmd *Command) ActionRunner {
+					_ = OutputArg(cmd, "output", tc.mode)
+
+					return func(cmd *Command) error {
+						return tc.err
+					}
+				}),
+			}
+
+			if err := app.Run(); err != tc.wantErr {
+				t.Fatalf("Run(): got error = %v, want error = %v", err, tc.wantErr)
+			}
+
+			data, err := ioutil.ReadFile(name)
+			if err != nil {
+				t.Fatalf("ReadFile(): failed to read: %s", err)
+			}
+
+			if got := string(data); got != tc.want {
+				t.Errorf("Run(): got = %q, want = %q", got, tc.want)
+			}
+
+			files, err := ioutil.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("ReadDir(): failed to read: %s", err)
+			}
+
+			if len(files) != 1 {
+				t.Errorf("Run(): temporary files are left: got %d files, want 1", len(files))
+			}
+		})
+	}
+}
+
+func TestApp_Run_files_command_flag(t *testing.T) {
+	modes := []struct {
+		name string
+		mode OutputMode
+	}{
+		{"create", OutputCreate},
+		{"atomic", OutputAtomic},
+	}
+
+	for _, tc := range modes {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writeTempFiles(t, map[string]string{
+				"out.txt": "precious",
+			})
+			defer os.RemoveAll(dir)
+			name := filepath.Join(dir, "out.txt")
+
+			var buf strings.Builder
+
+			app := App{
+				Name:   "write",
+				Args:   []string{"--output", name, "--help"},
+				Stdout: &buf,
+				CommandFlags: []CommandFlag{
+					HelpCommandFlag(),
+				},
+				Action: ActionFunc(func(cmd *Command) ActionRunner {
+					_ = Output(cmd, "output", tc.mode)
+
+					return func(cmd *Command) error { panic("not implemented") }
+				}),
+			}
+
+			if err := app.Run(); err != nil {
+				t.Fatalf("Run(): failed to run: %s", err)
+			}
+
+			data, err := ioutil.ReadFile(name)
+			if err != nil {
+				t.Fatalf("ReadFile(): failed to read: %s", err)
+			}
+
+			if got := string(data); got != "precious" {
+				t.Errorf("Run(): got = %q, want = %q", got, "precious")
+			}
+
+			files, err := ioutil.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("ReadDir(): failed to read: %s", err)
+			}
+
+			if len(files) != 1 {
+				t.Errorf("Run(): temporary files are left: got %d files, want 1", len(files))
+			}
+		})
+	}
+}
+
+func TestZSHCompletionGenerator_files(t *testing.T) {
+	app := &App{
+		Name: "test",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Output(cmd, "output", OutputCreate)
+			_ = InputArg(cmd, "input")
+			_ = RestInputs(cmd, "files")
+
+			return nil
+		}),
+	}
+
+	got := generateZSHCompletion(t, app)
+
+	assertContains(t, got,
+		`--output'='': :_files'`,
+		`'1:input:_files'`,
+		`'*::files:_files'`,
+	)
+}
+
+func TestDefaultHelper_Help_files(t *testing.T) {
+	app := App{
+		Name: "files",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Output(cmd, "output", OutputCreate)
+			_ = InputArg(cmd, "input")
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+	}
+
+	cmd, err := app.Command("files")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := (DefaultHelper{}).Help(cmd, &buf); err != nil {
+		t.Fatalf("Help(): failed to write help: %s", err)
+	}
+
+	assertContains(t, buf.String(),
+		"<input> file",
+		"--output file",
+	)
+}
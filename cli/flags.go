@@ -4,22 +4,35 @@ type Flag struct {
 	Value     Value
 	Short     string
 	Long      string
+	Aliases   []string // Additional short and long names.
 	Usage     Usager
 	Necessary Necessary
+	Env       string
+	Hidden    bool
+	Negatable bool
+	FromFile  bool
+	Sensitive bool
+	Global    bool
+
+	ImplicitValue *string
+	ValueName     string
+
+	Deprecation *Deprecation
+
+	Validators []Validator
 
 	set          bool
 	defaultSaved bool
 	defaultValue string
 	defaultEmpty bool
 	commandFlag  bool
+	object       string
+	inherited    bool   // Global flag of a parent command.
+	onSet        func() // Called by MarkSet.
 
-	// NOTE(SuperPaintman):
-	//     The first version had "Aliases" for flags. It's quite handy to have
-	//     (e.g. --dry and --dry-run) but at the same time makes API a bit
-	//     confusing because of duplication logic.
-	//
-	//     I decided to remove aliases. It's not so commonly used feature and
-	//     developers can easely make a workaround if they need it.
+	// Aliases are additional names of the same flag (e.g. --dry and
+	// --dry-run), not separate flags bound to one variable, so required
+	// checks, help and completions treat them as one flag.
 }
 
 func newFlag(value Value, opts FlagOptions) Flag {
@@ -27,10 +40,25 @@ func newFlag(value Value, opts FlagOptions) Flag {
 		Value:     value,
 		Short:     opts.Short,
 		Long:      opts.Long,
+		Aliases:   opts.Aliases,
 		Usage:     opts.Usage,
 		Necessary: opts.Necessary,
+		Env:       opts.Env,
+		Hidden:    opts.Hidden,
+		Negatable: opts.Negatable,
+		FromFile:  opts.FromFile,
+		Sensitive: opts.Sensitive,
+		Global:    opts.Global,
+
+		ImplicitValue: opts.ImplicitValue,
+		ValueName:     opts.ValueName,
+
+		Deprecation: opts.Deprecation,
+
+		Validators: opts.Validators,
 
 		commandFlag: opts.commandFlag,
+		object:      opts.object,
 	}
 }
 
@@ -42,6 +70,116 @@ func (f *Flag) Type() string {
 	return ""
 }
 
+// ShortNames returns the short name and short aliases of the flag.
+func (f *Flag) ShortNames() []string {
+	var names []string
+	if f.Short != "" {
+		names = append(names, f.Short)
+	}
+
+	for _, alias := range f.Aliases {
+		if len(alias) == 1 {
+			names = append(names, alias)
+		}
+	}
+
+	return names
+}
+
+// LongNames returns the long name and long aliases of the flag.
+func (f *Flag) LongNames() []string {
+	var names []string
+	if f.Long != "" {
+		names = append(names, f.Long)
+	}
+
+	for _, alias := range f.Aliases {
+		if len(alias) > 1 {
+			names = append(names, alias)
+		}
+	}
+
+	return names
+}
+
+// NegativeLongs returns the "no-<long>" names of a negatable flag for the long
+// name and all long aliases.
+func (f *Flag) NegativeLongs() []string {
+	if !f.Negatable {
+		return nil
+	}
+
+	names := f.LongNames()
+	for i := range names {
+		names[i] = negativePrefix + names[i]
+	}
+
+	return names
+}
+
+func (f *Flag) isLongName(name string) bool {
+	for _, long := range f.LongNames() {
+		if long == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *Flag) isNegativeLong(name string) bool {
+	if f.isLongName(name) {
+		return false
+	}
+
+	for _, negative := range f.NegativeLongs() {
+		if negative == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FileLongs returns the "<long>-file" names of a flag which reads its value
+// from a file for the long name and all long aliases.
+func (f *Flag) FileLongs() []string {
+	if !f.FromFile {
+		return nil
+	}
+
+	names := f.LongNames()
+	for i := range names {
+		names[i] += fileSuffix
+	}
+
+	return names
+}
+
+func (f *Flag) isFileLong(name string) bool {
+	if f.isLongName(name) {
+		return false
+	}
+
+	for _, long := range f.FileLongs() {
+		if long == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DisplayValue returns the value of the flag for the help and logs. Values of
+// sensitive flags are masked.
+func (f *Flag) DisplayValue() string {
+	if f.Sensitive {
+		return sensitiveMask
+	}
+
+	return f.Value.String()
+}
+
 func (f *Flag) Required() bool {
 	return f.Necessary == Required
 }
@@ -52,6 +190,10 @@ func (f *Flag) Set() bool {
 
 func (f *Flag) MarkSet() {
 	f.set = true
+
+	if f.onSet != nil {
+		f.onSet()
+	}
 }
 
 func (f *Flag) Default() (v string, empty bool) {
@@ -96,6 +238,32 @@ func (f *Flag) String() string {
 	return v
 }
 
+const negativePrefix = "no-"
+
+const fileSuffix = "-file"
+
+func visibleFlags(flags []Flag) []Flag {
+	res := make([]Flag, 0, len(flags))
+	for i := range flags {
+		if !flags[i].Hidden {
+			res = append(res, flags[i])
+		}
+	}
+
+	return res
+}
+
+func actualFlags(flags []Flag) []Flag {
+	res := make([]Flag, 0, len(flags))
+	for i := range flags {
+		if flags[i].Deprecation == nil {
+			res = append(res, flags[i])
+		}
+	}
+
+	return res
+}
+
 func Var(register Register, value Value, name string, options ...FlagOptionApplyer) error {
 	var opts FlagOptions
 	opts.applyName(name)
@@ -107,3 +275,5 @@ func Var(register Register, value Value, name string, options ...FlagOptionApply
 //go:generate python ./generate_flags.py
 
 //go:generate python ./generate_multi_flags.py
+
+//go:generate python ./generate_reflect_values.py
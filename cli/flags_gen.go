@@ -3,6 +3,8 @@
 package cli
 
 import (
+	"net"
+	"net/url"
 	"time"
 )
 
@@ -875,3 +877,177 @@ func Duration(register Register, name string, options ...FlagOptionApplyer) *tim
 	_ = DurationVar(register, p, name, options...)
 	return p
 }
+
+// net.IP
+
+// IPVar defines a net.IP flag with specified name.
+// The argument p points to a net.IP variable in which to store the value of the flag.
+// The return value will be an error from the register.RegisterFlag if it
+// failed to register the flag.
+//
+// If a name contains only one rune, it will be a short name, otherwise a long name.
+// To set a short name, you pass a cli.WithShort.
+//
+//   _ = cli.IPVar(register, &p, "name", cli.WithShort("n"))
+//
+// To set a long name, you pass a cli.WithLong.
+//
+//   _ = cli.IPVar(register, &p, "n", cli.WithLong("name"))
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.IPVar(register, &p, "name", cli.Usage("The name of user"))
+//
+// The flag is optional by default.
+// This may be changed by passing the cli.Required.
+//
+//   _ = cli.IPVar(register, &p, "name", cli.Required)
+//
+// All options can be used together.
+func IPVar(register Register, p *net.IP, name string, options ...FlagOptionApplyer) error {
+	return Var(register, newIPValue(p), name, options...)
+}
+
+// IP defines a net.IP flag with specified name.
+// The return value is the address of a net.IP variable that stores the value of the flag.
+//
+// If a name contains only one rune, it will be a short name, otherwise a long name.
+// To set a short name, you pass a cli.WithShort.
+//
+//   _ = cli.IP(register, "name", cli.WithShort("n"))
+//
+// To set a long name, you pass a cli.WithLong.
+//
+//   _ = cli.IP(register, "n", cli.WithLong("name"))
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.IP(register, "name", cli.Usage("The name of user"))
+//
+// The flag is optional by default.
+// This may be changed by passing the cli.Required.
+//
+//   _ = cli.IP(register, "name", cli.Required)
+//
+// All options can be used together.
+func IP(register Register, name string, options ...FlagOptionApplyer) *net.IP {
+	p := new(net.IP)
+	_ = IPVar(register, p, name, options...)
+	return p
+}
+
+// net.IPNet
+
+// IPNetVar defines a net.IPNet flag with specified name.
+// The argument p points to a net.IPNet variable in which to store the value of the flag.
+// The return value will be an error from the register.RegisterFlag if it
+// failed to register the flag.
+//
+// If a name contains only one rune, it will be a short name, otherwise a long name.
+// To set a short name, you pass a cli.WithShort.
+//
+//   _ = cli.IPNetVar(register, &p, "name", cli.WithShort("n"))
+//
+// To set a long name, you pass a cli.WithLong.
+//
+//   _ = cli.IPNetVar(register, &p, "n", cli.WithLong("name"))
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.IPNetVar(register, &p, "name", cli.Usage("The name of user"))
+//
+// The flag is optional by default.
+// This may be changed by passing the cli.Required.
+//
+//   _ = cli.IPNetVar(register, &p, "name", cli.Required)
+//
+// All options can be used together.
+func IPNetVar(register Register, p *net.IPNet, name string, options ...FlagOptionApplyer) error {
+	return Var(register, newIPNetValue(p), name, options...)
+}
+
+// IPNet defines a net.IPNet flag with specified name.
+// The return value is the address of a net.IPNet variable that stores the value of the flag.
+//
+// If a name contains only one rune, it will be a short name, otherwise a long name.
+// To set a short name, you pass a cli.WithShort.
+//
+//   _ = cli.IPNet(register, "name", cli.WithShort("n"))
+//
+// To set a long name, you pass a cli.WithLong.
+//
+//   _ = cli.IPNet(register, "n", cli.WithLong("name"))
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.IPNet(register, "name", cli.Usage("The name of user"))
+//
+// The flag is optional by default.
+// This may be changed by passing the cli.Required.
+//
+//   _ = cli.IPNet(register, "name", cli.Required)
+//
+// All options can be used together.
+func IPNet(register Register, name string, options ...FlagOptionApplyer) *net.IPNet {
+	p := new(net.IPNet)
+	_ = IPNetVar(register, p, name, options...)
+	return p
+}
+
+// url.URL
+
+// URLVar defines a url.URL flag with specified name.
+// The argument p points to a url.URL variable in which to store the value of the flag.
+// The return value will be an error from the register.RegisterFlag if it
+// failed to register the flag.
+//
+// If a name contains only one rune, it will be a short name, otherwise a long name.
+// To set a short name, you pass a cli.WithShort.
+//
+//   _ = cli.URLVar(register, &p, "name", cli.WithShort("n"))
+//
+// To set a long name, you pass a cli.WithLong.
+//
+//   _ = cli.URLVar(register, &p, "n", cli.WithLong("name"))
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.URLVar(register, &p, "name", cli.Usage("The name of user"))
+//
+// The flag is optional by default.
+// This may be changed by passing the cli.Required.
+//
+//   _ = cli.URLVar(register, &p, "name", cli.Required)
+//
+// All options can be used together.
+func URLVar(register Register, p *url.URL, name string, options ...FlagOptionApplyer) error {
+	return Var(register, newURLValue(p), name, options...)
+}
+
+// URL defines a url.URL flag with specified name.
+// The return value is the address of a url.URL variable that stores the value of the flag.
+//
+// If a name contains only one rune, it will be a short name, otherwise a long name.
+// To set a short name, you pass a cli.WithShort.
+//
+//   _ = cli.URL(register, "name", cli.WithShort("n"))
+//
+// To set a long name, you pass a cli.WithLong.
+//
+//   _ = cli.URL(register, "n", cli.WithLong("name"))
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.URL(register, "name", cli.Usage("The name of user"))
+//
+// The flag is optional by default.
+// This may be changed by passing the cli.Required.
+//
+//   _ = cli.URL(register, "name", cli.Required)
+//
+// All options can be used together.
+func URL(register Register, name string, options ...FlagOptionApplyer) *url.URL {
+	p := new(url.URL)
+	_ = URLVar(register, p, name, options...)
+	return p
+}
@@ -3,6 +3,8 @@
 package cli
 
 import (
+	"net"
+	"net/url"
 	"time"
 )
 
@@ -875,3 +877,177 @@ func Durations(register Register, name string, options ...FlagOptionApplyer) *[]
 	_ = DurationsVar(register, p, name, options...)
 	return p
 }
+
+// []net.IP
+
+// IPsVar defines a []net.IP flag with specified name.
+// The argument p points to a []net.IP variable in which to store values of the flag.
+// The return value will be an error from the register.RegisterFlag if it
+// failed to register the flag.
+//
+// If a name contains only one rune, it will be a short name, otherwise a long name.
+// To set a short name, you pass a cli.WithShort.
+//
+//   _ = cli.IPsVar(register, &p, "names", cli.WithShort("n"))
+//
+// To set a long name, you pass a cli.WithLong.
+//
+//   _ = cli.IPsVar(register, &p, "n", cli.WithLong("names"))
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.IPsVar(register, &p, "names", cli.Usage("Names of users"))
+//
+// The flag is optional by default.
+// This may be changed by passing the cli.Required.
+//
+//   _ = cli.IPsVar(register, &p, "names", cli.Required)
+//
+// All options can be used together.
+func IPsVar(register Register, p *[]net.IP, name string, options ...FlagOptionApplyer) error {
+	return Var(register, newIPValues(p), name, options...)
+}
+
+// IPs defines a []net.IP flag with specified name.
+// The return value is the address of a []net.IP variable that stores values of the flag.
+//
+// If a name contains only one rune, it will be a short name, otherwise a long name.
+// To set a short name, you pass a cli.WithShort.
+//
+//   _ = cli.IPs(register, "names", cli.WithShort("n"))
+//
+// To set a long name, you pass a cli.WithLong.
+//
+//   _ = cli.IPs(register, "n", cli.WithLong("names"))
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.IPs(register, "names", cli.Usage("Names of users"))
+//
+// The flag is optional by default.
+// This may be changed by passing the cli.Required.
+//
+//   _ = cli.IPs(register, "names", cli.Required)
+//
+// All options can be used together.
+func IPs(register Register, name string, options ...FlagOptionApplyer) *[]net.IP {
+	p := new([]net.IP)
+	_ = IPsVar(register, p, name, options...)
+	return p
+}
+
+// []net.IPNet
+
+// IPNetsVar defines a []net.IPNet flag with specified name.
+// The argument p points to a []net.IPNet variable in which to store values of the flag.
+// The return value will be an error from the register.RegisterFlag if it
+// failed to register the flag.
+//
+// If a name contains only one rune, it will be a short name, otherwise a long name.
+// To set a short name, you pass a cli.WithShort.
+//
+//   _ = cli.IPNetsVar(register, &p, "names", cli.WithShort("n"))
+//
+// To set a long name, you pass a cli.WithLong.
+//
+//   _ = cli.IPNetsVar(register, &p, "n", cli.WithLong("names"))
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.IPNetsVar(register, &p, "names", cli.Usage("Names of users"))
+//
+// The flag is optional by default.
+// This may be changed by passing the cli.Required.
+//
+//   _ = cli.IPNetsVar(register, &p, "names", cli.Required)
+//
+// All options can be used together.
+func IPNetsVar(register Register, p *[]net.IPNet, name string, options ...FlagOptionApplyer) error {
+	return Var(register, newIPNetValues(p), name, options...)
+}
+
+// IPNets defines a []net.IPNet flag with specified name.
+// The return value is the address of a []net.IPNet variable that stores values of the flag.
+//
+// If a name contains only one rune, it will be a short name, otherwise a long name.
+// To set a short name, you pass a cli.WithShort.
+//
+//   _ = cli.IPNets(register, "names", cli.WithShort("n"))
+//
+// To set a long name, you pass a cli.WithLong.
+//
+//   _ = cli.IPNets(register, "n", cli.WithLong("names"))
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.IPNets(register, "names", cli.Usage("Names of users"))
+//
+// The flag is optional by default.
+// This may be changed by passing the cli.Required.
+//
+//   _ = cli.IPNets(register, "names", cli.Required)
+//
+// All options can be used together.
+func IPNets(register Register, name string, options ...FlagOptionApplyer) *[]net.IPNet {
+	p := new([]net.IPNet)
+	_ = IPNetsVar(register, p, name, options...)
+	return p
+}
+
+// []url.URL
+
+// URLsVar defines a []url.URL flag with specified name.
+// The argument p points to a []url.URL variable in which to store values of the flag.
+// The return value will be an error from the register.RegisterFlag if it
+// failed to register the flag.
+//
+// If a name contains only one rune, it will be a short name, otherwise a long name.
+// To set a short name, you pass a cli.WithShort.
+//
+//   _ = cli.URLsVar(register, &p, "names", cli.WithShort("n"))
+//
+// To set a long name, you pass a cli.WithLong.
+//
+//   _ = cli.URLsVar(register, &p, "n", cli.WithLong("names"))
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.URLsVar(register, &p, "names", cli.Usage("Names of users"))
+//
+// The flag is optional by default.
+// This may be changed by passing the cli.Required.
+//
+//   _ = cli.URLsVar(register, &p, "names", cli.Required)
+//
+// All options can be used together.
+func URLsVar(register Register, p *[]url.URL, name string, options ...FlagOptionApplyer) error {
+	return Var(register, newURLValues(p), name, options...)
+}
+
+// URLs defines a []url.URL flag with specified name.
+// The return value is the address of a []url.URL variable that stores values of the flag.
+//
+// If a name contains only one rune, it will be a short name, otherwise a long name.
+// To set a short name, you pass a cli.WithShort.
+//
+//   _ = cli.URLs(register, "names", cli.WithShort("n"))
+//
+// To set a long name, you pass a cli.WithLong.
+//
+//   _ = cli.URLs(register, "n", cli.WithLong("names"))
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.URLs(register, "names", cli.Usage("Names of users"))
+//
+// The flag is optional by default.
+// This may be changed by passing the cli.Required.
+//
+//   _ = cli.URLs(register, "names", cli.Required)
+//
+// All options can be used together.
+func URLs(register Register, name string, options ...FlagOptionApplyer) *[]url.URL {
+	p := new([]url.URL)
+	_ = URLsVar(register, p, name, options...)
+	return p
+}
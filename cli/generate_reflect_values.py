@@ -0,0 +1,33 @@
+#!/usr/bin/env python
+
+from gotypes import types, imports
+
+res = "// Code generated by generate_reflect_values.py; DO NOT EDIT.\n"
+res += "\n"
+res += "package cli\n"
+res += "\n"
+res += "import (\n"
+for pkg in imports:
+    res += "\t\"%s\"\n" % pkg
+res += ")\n"
+res += "\n"
+res += "// newValueOf returns a Value for the pointer p if a type of p is supported.\n"
+res += "func newValueOf(p interface{}) (Value, bool) {\n"
+res += "\tswitch p := p.(type) {\n"
+
+for (typ, name, _, _) in types:
+    res += "\t// %s\n" % typ
+    res += "\tcase *%s:\n" % typ
+    res += "\t\treturn new%sValue(p), true\n" % name
+    res += "\n"
+    res += "\tcase *[]%s:\n" % typ
+    res += "\t\treturn new%sValues(p), true\n" % name
+    res += "\n"
+
+res += "\tdefault:\n"
+res += "\t\treturn nil, false\n"
+res += "\t}\n"
+res += "}\n"
+
+with open("./value_reflect_gen.go", "w") as f:
+    f.write(res)
@@ -22,10 +22,11 @@ for (typ, name, _, _) in types:
     res += "// []%s\n" % typ
     res += "\n"
     res += "var (\n"
-    res += "\t_ Value   = (*%sValues)(nil)\n" % safe_typ
-    res += "\t_ Getter  = (*%sValues)(nil)\n" % safe_typ
-    res += "\t_ Emptier = (*%sValues)(nil)\n" % safe_typ
-    res += "\t_ Typer   = (*%sValues)(nil)\n" % safe_typ
+    res += "\t_ Value      = (*%sValues)(nil)\n" % safe_typ
+    res += "\t_ Getter     = (*%sValues)(nil)\n" % safe_typ
+    res += "\t_ Emptier    = (*%sValues)(nil)\n" % safe_typ
+    res += "\t_ Typer      = (*%sValues)(nil)\n" % safe_typ
+    res += "\t_ sliceValue = (*%sValues)(nil)\n" % safe_typ
     res += ")\n"
     res += "\n"
     res += "type %sValues []%s\n" % (safe_typ, typ)
@@ -77,6 +78,8 @@ for (typ, name, _, _) in types:
     res += "func (v *%sValues) Get() interface{} { return []%s(*v) }\n" % (safe_typ, typ)
     res += "\n"
     res += "func (*%sValues) Type() string { return \"[]%s\" }\n" % (safe_typ, typ)
+    res += "\n"
+    res += "func (*%sValues) IsSliceValue() bool { return true }\n" % safe_typ
 
 with open("./values_gen.go", "w") as f:
     f.write(res)
@@ -16,10 +16,15 @@ types = [
     ("int", "Int", "strconv.Itoa(int(*%s))", "*%s == 0"),
     ("uint", "Uint", "strconv.FormatUint(uint64(*%s), 10)", "*%s == 0"),
     ("time.Duration", "Duration", "(*time.Duration)(%s).String()", "*%s == 0"),
+    ("net.IP", "IP", "ipString(net.IP(*%s))", "len(*%s) == 0"),
+    ("net.IPNet", "IPNet", "ipNetString((*net.IPNet)(%s))", "len(%s.IP) == 0"),
+    ("url.URL", "URL", "(*url.URL)(%s).String()", "(*url.URL)(%s).String() == \"\""),
     # TODO: Func
 ]
 
 imports = [
+    "net",
+    "net/url",
     "time"
 ]
 
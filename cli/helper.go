@@ -2,7 +2,9 @@ package cli
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"strings"
 
 	"github.com/SuperPaintman/nice/colors"
 )
@@ -33,7 +35,10 @@ func DisableHelp() Helper {
 
 var _ Helper = DefaultHelper{}
 
-type DefaultHelper struct{}
+type DefaultHelper struct {
+	ShowHidden     bool // Show hidden commands, flags and args.
+	HideDeprecated bool // Hide deprecated commands, flags and args.
+}
 
 func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 	const (
@@ -43,6 +48,7 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 		colorOption   = colors.Yellow
 		colorType     = colors.Green
 		colorDefault  = colors.Blue
+		colorEnv      = colors.Cyan
 	)
 
 	ew := easyWriter{w: w}
@@ -52,6 +58,43 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 	rest := cmd.Rest()
 	flags := cmd.Flags()
 
+	commands := make([]*Command, 0, len(cmd.Commands))
+	for i := range cmd.Commands {
+		c := &cmd.Commands[i]
+
+		if (h.ShowHidden || !c.Hidden) && (!h.HideDeprecated || c.Deprecation == nil) {
+			commands = append(commands, c)
+		}
+	}
+
+	if !h.ShowHidden {
+		args = visibleArgs(args)
+		flags = visibleFlags(flags)
+	}
+
+	if h.HideDeprecated {
+		args = actualArgs(args)
+		flags = actualFlags(flags)
+	}
+
+	// Deprecated items are marked as "deprecated: use <replacement>".
+	deprecationNote := func(d *Deprecation) string {
+		if d.Replacement == "" {
+			return "deprecated"
+		}
+
+		replacement := d.Replacement
+		if _, _, ok := findArg(cmd.Args(), replacement); ok {
+			replacement = "<" + replacement + ">"
+		} else if _, ok := cmd.LongFlag(replacement); ok {
+			replacement = cmd.Parser().FormatLongFlag(replacement)
+		} else if _, ok := cmd.ShortFlag(replacement); ok {
+			replacement = cmd.Parser().FormatShortFlag(replacement)
+		}
+
+		return "deprecated: use " + replacement
+	}
+
 	// Usage with argumens.
 	ew.Writef("Usage:")
 
@@ -75,6 +118,10 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 		ew.Writef(" %s[%s...]%s", colorArgument, rest.Name, colorArgument.Reset())
 	}
 
+	if pt := cmd.Passthrough(); pt != nil {
+		ew.Writef(" %s[-- %s...]%s", colorArgument, pt.Name, colorArgument.Reset())
+	}
+
 	ew.Writef("\n")
 
 	if err := ew.Err(); err != nil {
@@ -82,7 +129,7 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 	}
 
 	// Usage with a command.
-	if len(cmd.Commands) > 0 {
+	if len(commands) > 0 {
 		ew.Writef("      ")
 
 		for _, name := range path {
@@ -116,24 +163,28 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 	}
 
 	// Commands.
-	if len(cmd.Commands) > 0 {
+	if len(commands) > 0 {
 		ew.Writef("\n")
 		ew.Writef("Commands:\n")
 
+		// Aliases are shown next to the name: "remove, rm".
+		commandName := func(cmd *Command) string {
+			return strings.Join(append([]string{cmd.Name}, cmd.Aliases...), ", ")
+		}
+
 		var maxLen int
-		for _, cmd := range cmd.Commands {
-			if len(cmd.Name) > maxLen {
-				maxLen = len(cmd.Name)
+		for _, cmd := range commands {
+			if l := len(commandName(cmd)); l > maxLen {
+				maxLen = l
 			}
 		}
 
-		for i := range cmd.Commands {
-			cmd := &cmd.Commands[i]
-
+		for _, cmd := range commands {
 			// Name.
-			ew.Writef("  %s%s%s", colorCommand, cmd.Name, colorCommand.Reset())
+			ew.Writef("  %s%s%s", colorCommand, commandName(cmd), colorCommand.Reset())
 
 			// Usage.
+			var hasUsage bool
 			if cmd.Usage != nil {
 				// TODO(SuperPaintman): optimize it.
 				var buf bytes.Buffer
@@ -143,13 +194,34 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 				usage := buf.String()
 
 				if usage != "" {
-					indent := 4 + maxLen - len(cmd.Name)
+					indent := 4 + maxLen - len(commandName(cmd))
 					for i := 0; i < indent; i++ {
 						ew.WriteString(" ")
 					}
 
 					ew.Writef("%s", usage)
+
+					hasUsage = true
+				}
+			}
+
+			// Deprecation.
+			if cmd.Deprecation != nil {
+				if !hasUsage {
+					indent := 4 + maxLen - len(commandName(cmd))
+					for i := 0; i < indent; i++ {
+						ew.WriteString(" ")
+					}
+				} else {
+					ew.WriteString(" ")
+				}
+
+				note := "deprecated"
+				if cmd.Deprecation.Replacement != "" {
+					note += ": use " + cmd.Deprecation.Replacement
 				}
+
+				ew.Writef("(%s)", note)
 			}
 
 			ew.Writef("\n")
@@ -217,8 +289,17 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 				}
 			}
 
-			// Default.
+			// Notes.
+			notes := validatorsNote(arg.Validators)
 			if value, empty := arg.Default(); !empty {
+				notes = append(notes, fmt.Sprintf("default: %s%v%s", colorDefault, value, colorDefault.Reset()))
+			}
+
+			if arg.Deprecation != nil {
+				notes = append(notes, deprecationNote(arg.Deprecation))
+			}
+
+			if len(notes) > 0 {
 				if !hasUsage {
 					indent := 4 + argMaxLen - (len(arg.Name) + 2 + len(arg.Type()) + 1)
 					for i := 0; i < indent; i++ {
@@ -228,7 +309,7 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 					ew.WriteString(" ")
 				}
 
-				ew.Writef("(default: %s%v%s)", colorDefault, value, colorDefault.Reset())
+				ew.Writef("(%s)", strings.Join(notes, ", "))
 			}
 
 			ew.Writef("\n")
@@ -282,8 +363,13 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 			}
 		}
 
-		// Default.
+		// Notes.
+		notes := validatorsNote(rest.Validators)
 		if value, empty := rest.Default(); !empty {
+			notes = append(notes, fmt.Sprintf("default: %s%v%s", colorDefault, value, colorDefault.Reset()))
+		}
+
+		if len(notes) > 0 {
 			if !hasUsage {
 				indent := 4 + argMaxLen - ((len(rest.Name) + 2 + len(rest.Type())) + 1 + 3)
 				for i := 0; i < indent; i++ {
@@ -293,7 +379,7 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 				ew.WriteString(" ")
 			}
 
-			ew.Writef("(default: %s%v%s)", colorDefault, value, colorDefault.Reset())
+			ew.Writef("(%s)", strings.Join(notes, ", "))
 		}
 
 		ew.Writef("\n")
@@ -305,8 +391,58 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 
 	// Options.
 	if len(flags) > 0 {
-		ew.Writef("\n")
-		ew.Writef("Options:\n")
+		// Negatable flags are shown as "--[no-]long" and objects with dynamic
+		// keys as "--name.<key>".
+		// Values are shown by the name of the value or by the type.
+		valueName := func(flag *Flag) string {
+			if flag.ValueName != "" {
+				return flag.ValueName
+			}
+
+			if t := flag.Type(); t != "" {
+				return t
+			}
+
+			return "(unknown)"
+		}
+
+		// Optional values are shown after long names: "--color[=<when>]".
+		optionalValue := func(flag *Flag) string {
+			if flag.ImplicitValue == nil {
+				return ""
+			}
+
+			return "[=<" + valueName(flag) + ">]"
+		}
+
+		// Aliases are shown next to the name: "--dry-run, --dry".
+		longFlag := func(flag *Flag) string {
+			names := flag.LongNames()
+			for i, name := range names {
+				if flag.Negatable {
+					names[i] = cmd.Parser().FormatLongFlag("[" + negativePrefix + "]" + name)
+				} else if fv, ok := flag.Value.(objectFlag); ok && fv.IsObjectFlag() {
+					names[i] = cmd.Parser().FormatLongFlag(name + ".<key>")
+				} else {
+					names[i] = cmd.Parser().FormatLongFlag(name)
+				}
+			}
+
+			if len(names) == 0 {
+				return ""
+			}
+
+			return strings.Join(names, ", ") + optionalValue(flag)
+		}
+
+		shortFlag := func(flag *Flag) string {
+			names := flag.ShortNames()
+			for i, name := range names {
+				names[i] = cmd.Parser().FormatShortFlag(name)
+			}
+
+			return strings.Join(names, ", ")
+		}
 
 		var (
 			maxLenShort int
@@ -314,8 +450,8 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 		)
 		for _, flag := range flags {
 			var l int
-			if flag.Short != "" {
-				shortLen := len(cmd.Parser().FormatShortFlag(flag.Short))
+			if short := shortFlag(&flag); short != "" {
+				shortLen := len(short)
 
 				if shortLen > maxLenShort {
 					maxLenShort = shortLen
@@ -324,20 +460,16 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 				l += shortLen
 			}
 
-			if flag.Long != "" {
+			if long := longFlag(&flag); long != "" {
 				if l != 0 {
 					l += 2
 				}
 
-				l += len(cmd.Parser().FormatLongFlag(flag.Long))
+				l += len(long)
 			}
 
-			if t := flag.Type(); t != "bool" {
-				if t == "" {
-					l += len("(unknown)") + 1
-				} else {
-					l += len(t) + 1
-				}
+			if t := flag.Type(); t != "bool" && t != "count" && (flag.ImplicitValue == nil || len(flag.LongNames()) == 0) {
+				l += len(valueName(&flag)) + 1
 			}
 
 			if l > maxLen {
@@ -345,14 +477,35 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 			}
 		}
 
-		for _, flag := range flags {
+		flagLen := func(flag *Flag) int {
+			l := len(shortFlag(flag))
+			if l == 0 {
+				l += maxLenShort
+			}
+
+			if long := longFlag(flag); long != "" {
+				if l != 0 {
+					l += 2
+				}
+
+				l += len(long)
+			}
+
+			if t := flag.Type(); t != "bool" && t != "count" && (flag.ImplicitValue == nil || len(flag.LongNames()) == 0) {
+				l += len(valueName(flag)) + 1
+			}
+
+			return l
+		}
+
+		writeFlag := func(flag *Flag) error {
 			ew.Writef("  ")
 
 			// Short.
-			if flag.Short != "" {
+			if short := shortFlag(flag); short != "" {
 				ew.Writef("%s%s%s",
 					colorOption,
-					cmd.Parser().FormatShortFlag(flag.Short),
+					short,
 					colorOption.Reset(),
 				)
 				ew.Writef(", ")
@@ -367,10 +520,10 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 			}
 
 			// Long.
-			if flag.Long != "" {
+			if long := longFlag(flag); long != "" {
 				ew.Writef("%s%s%s",
 					colorOption,
-					cmd.Parser().FormatLongFlag(flag.Long),
+					long,
 					colorOption.Reset(),
 				)
 			} else {
@@ -378,12 +531,8 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 			}
 
 			// Type.
-			if t := flag.Type(); t != "bool" {
-				if t == "" {
-					t = "(unknown)"
-				}
-
-				ew.Writef(" %s%s%s", colorType, t, colorType.Reset())
+			if t := flag.Type(); t != "bool" && t != "count" && (flag.ImplicitValue == nil || len(flag.LongNames()) == 0) {
+				ew.Writef(" %s%s%s", colorType, valueName(flag), colorType.Reset())
 			}
 
 			// Usage.
@@ -397,28 +546,7 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 				usage := buf.String()
 
 				if usage != "" {
-					l := len(cmd.Parser().FormatShortFlag(flag.Short))
-					if l == 0 {
-						l += maxLenShort
-					}
-
-					if flag.Long != "" {
-						if l != 0 {
-							l += 2
-						}
-
-						l += len(cmd.Parser().FormatLongFlag(flag.Long))
-					}
-
-					if t := flag.Type(); t != "bool" {
-						if t == "" {
-							l += len("(unknown)") + 1
-						} else {
-							l += len(t) + 1
-						}
-					}
-
-					indent := 4 + maxLen - l
+					indent := 4 + maxLen - flagLen(flag)
 					for i := 0; i < indent; i++ {
 						ew.WriteString(" ")
 					}
@@ -429,31 +557,37 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 				}
 			}
 
-			// Default.
+			// Notes.
+			var notes []string
+			if flag.Required() {
+				notes = append(notes, "required")
+			}
+
+			notes = append(notes, validatorsNote(flag.Validators)...)
+
 			if value, empty := flag.Default(); !empty {
-				if !hasUsage {
-					l := len(cmd.Parser().FormatShortFlag(flag.Short))
-					if l == 0 {
-						l += maxLenShort
-					}
+				if flag.Sensitive {
+					value = sensitiveMask
+				}
 
-					if flag.Long != "" {
-						if l != 0 {
-							l += 2
-						}
+				notes = append(notes, fmt.Sprintf("default: %s%v%s", colorDefault, value, colorDefault.Reset()))
+			}
 
-						l += len(cmd.Parser().FormatLongFlag(flag.Long))
-					}
+			if flag.FromFile && flag.Long != "" {
+				notes = append(notes, "file: "+cmd.Parser().FormatLongFlag(flag.Long+fileSuffix))
+			}
 
-					if t := flag.Type(); t != "bool" {
-						if t == "" {
-							l += len("(unknown)") + 1
-						} else {
-							l += len(t) + 1
-						}
-					}
+			if flag.Env != "" {
+				notes = append(notes, fmt.Sprintf("env: %s$%s%s", colorEnv, flag.Env, colorEnv.Reset()))
+			}
 
-					indent := 4 + maxLen - l
+			if flag.Deprecation != nil {
+				notes = append(notes, deprecationNote(flag.Deprecation))
+			}
+
+			if len(notes) > 0 {
+				if !hasUsage {
+					indent := 4 + maxLen - flagLen(flag)
 					for i := 0; i < indent; i++ {
 						ew.WriteString(" ")
 					}
@@ -461,46 +595,127 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 					ew.WriteString(" ")
 				}
 
-				if flag.Required() {
-					ew.Writef("(required, default: %s%v%s)", colorDefault, value, colorDefault.Reset())
-				} else {
-					ew.Writef("(default: %s%v%s)", colorDefault, value, colorDefault.Reset())
+				ew.Writef("(%s)", strings.Join(notes, ", "))
+			}
+
+			ew.Writef("\n")
+
+			return ew.Err()
+		}
+
+		// Flags of objects are grouped under their own headings.
+		var (
+			objects    []string
+			hasOptions bool
+		)
+		for i := range flags {
+			flag := &flags[i]
+
+			if flag.inherited {
+				continue
+			}
+
+			if flag.object == "" {
+				if !hasOptions {
+					ew.Writef("\n")
+					ew.Writef("Options:\n")
+
+					hasOptions = true
 				}
-			} else if flag.Required() {
-				if !hasUsage {
-					l := len(cmd.Parser().FormatShortFlag(flag.Short))
-					if l == 0 {
-						l += maxLenShort
-					}
 
-					if flag.Long != "" {
-						if l != 0 {
-							l += 2
-						}
+				if err := writeFlag(flag); err != nil {
+					return err
+				}
 
-						l += len(cmd.Parser().FormatLongFlag(flag.Long))
-					}
+				continue
+			}
 
-					if t := flag.Type(); t != "bool" {
-						if t == "" {
-							l += len("(unknown)") + 1
-						} else {
-							l += len(t) + 1
-						}
-					}
+			var found bool
+			for _, object := range objects {
+				if object == flag.object {
+					found = true
+					break
+				}
+			}
 
-					indent := 4 + maxLen - l
-					for i := 0; i < indent; i++ {
-						ew.WriteString(" ")
-					}
+			if !found {
+				objects = append(objects, flag.object)
+			}
+		}
+
+		for _, object := range objects {
+			ew.Writef("\n")
+			ew.Writef("Options (%s%s%s):\n", colorOption, cmd.Parser().FormatLongFlag(object+".*"), colorOption.Reset())
+
+			for i := range flags {
+				if flags[i].object != object {
+					continue
+				}
+
+				if err := writeFlag(&flags[i]); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Global flags of parents.
+		var hasInherited bool
+		for i := range flags {
+			flag := &flags[i]
+
+			if !flag.inherited {
+				continue
+			}
+
+			if !hasInherited {
+				ew.Writef("\n")
+				ew.Writef("Inherited options:\n")
+
+				hasInherited = true
+			}
+
+			if err := writeFlag(flag); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Constraints.
+	constraints := cmd.Constraints()
+	if !h.ShowHidden {
+		constraints = visibleConstraints(cmd, constraints)
+	}
+
+	if len(constraints) > 0 {
+		ew.Writef("\n")
+		ew.Writef("Constraints:\n")
+
+		formatFlags := func(names []string) string {
+			res := make([]string, len(names))
+			for i, name := range names {
+				if len(name) == 1 {
+					res[i] = cmd.Parser().FormatShortFlag(name)
 				} else {
-					ew.WriteString(" ")
+					res[i] = cmd.Parser().FormatLongFlag(name)
 				}
 
-				ew.Writef("(required)")
+				res[i] = colorOption.String() + res[i] + colorOption.Reset().String()
 			}
 
-			ew.Writef("\n")
+			return strings.Join(res, ", ")
+		}
+
+		for _, c := range constraints {
+			switch c.Kind {
+			case MutuallyExclusiveConstraint:
+				ew.Writef("  %s: mutually exclusive\n", formatFlags(c.Flags))
+
+			case RequiredTogetherConstraint:
+				ew.Writef("  %s: required together\n", formatFlags(c.Flags))
+
+			case RequiredUnlessConstraint:
+				ew.Writef("  %s: required unless %s\n", formatFlags(c.Flags[:1]), formatFlags(c.Flags[1:]))
+			}
 		}
 
 		if err := ew.Err(); err != nil {
@@ -510,3 +725,36 @@ func (h DefaultHelper) Help(cmd *Command, w io.Writer) error {
 
 	return nil
 }
+
+// visibleConstraints returns constraints without hidden flags. Constraints
+// which make no sense without hidden flags are dropped.
+func visibleConstraints(r Register, constraints []Constraint) []Constraint {
+	var res []Constraint
+	for _, c := range constraints {
+		var names []string
+		for i, name := range c.Flags {
+			if flag, ok := lookupFlag(r, name); ok && flag.Hidden {
+				// The required flag itself is hidden.
+				if c.Kind == RequiredUnlessConstraint && i == 0 {
+					names = nil
+					break
+				}
+
+				continue
+			}
+
+			names = append(names, name)
+		}
+
+		if len(names) < 2 {
+			continue
+		}
+
+		res = append(res, Constraint{
+			Kind:  c.Kind,
+			Flags: names,
+		})
+	}
+
+	return res
+}
@@ -150,6 +150,572 @@ func TestDefaultHelper_Help(t *testing.T) {
 	}
 }
 
+func TestDefaultHelper_Help_env(t *testing.T) {
+	app := App{
+		Name: "env",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = String(cmd, "config",
+				WithShort("c"),
+				Usage("Path to the config"),
+				WithEnv("APP_CONFIG"),
+			)
+
+			jobs := Int(cmd, "jobs",
+				WithShort("j"),
+				WithEnv("APP_JOBS"),
+			)
+			*jobs = 4
+
+			_ = String(cmd, "token",
+				WithShort("t"),
+				Required,
+				WithEnv("APP_TOKEN"),
+			)
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+	}
+
+	cmd, err := app.Command("env")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := (DefaultHelper{}).Help(cmd, &buf); err != nil {
+		t.Fatalf("Help(): failed to write help: %s", err)
+	}
+
+	want := `Usage: env [options...]
+
+Options:
+  -c, --config string    Path to the config (env: $APP_CONFIG)
+  -j, --jobs int         (default: 4, env: $APP_JOBS)
+  -t, --token string     (required, env: $APP_TOKEN)
+`
+
+	assertStringsDiff(t, buf.String(), want)
+}
+
+func TestDefaultHelper_Help_deprecated(t *testing.T) {
+	app := App{
+		Name: "deprecated",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = StringArg(cmd, "path",
+				Optional,
+				Deprecation{Replacement: "dir"},
+			)
+
+			_ = Int(cmd, "jobs",
+				WithShort("j"),
+			)
+
+			_ = Int(cmd, "threads",
+				WithShort("t"),
+				Usage("Number of threads"),
+				Deprecation{Replacement: "jobs"},
+			)
+
+			_ = String(cmd, "dir",
+				WithShort("d"),
+			)
+
+			_ = Bool(cmd, "legacy",
+				WithShort("l"),
+				Deprecated("it will be removed"),
+			)
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+		Commands: []Command{
+			{
+				Name:   "run",
+				Usage:  Usage("Run the job"),
+				Action: ActionFunc(func(cmd *Command) ActionRunner { return nil }),
+			},
+			{
+				Name:        "start",
+				Usage:       Usage("Start the job"),
+				Action:      ActionFunc(func(cmd *Command) ActionRunner { return nil }),
+				Deprecation: &Deprecation{Replacement: "run"},
+			},
+		},
+	}
+
+	cmd, err := app.Command("deprecated")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	tt := []struct {
+		name   string
+		helper DefaultHelper
+		want   string
+	}{
+		{
+			name:   "marked",
+			helper: DefaultHelper{},
+			want: `Usage: deprecated [options...] [path]
+       deprecated [options...] [command]
+
+Commands:
+  run      Run the job
+  start    Start the job (deprecated: use run)
+
+Arguments:
+  [path] string    (deprecated: use --dir)
+
+Options:
+  -j, --jobs int
+  -t, --threads int    Number of threads (deprecated: use --jobs)
+  -d, --dir string
+  -l, --legacy         (deprecated)
+`,
+		},
+		{
+			name:   "hidden",
+			helper: DefaultHelper{HideDeprecated: true},
+			want: `Usage: deprecated [options...]
+       deprecated [options...] [command]
+
+Commands:
+  run    Run the job
+
+Options:
+  -j, --jobs int
+  -d, --dir string
+`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf strings.Builder
+			if err := tc.helper.Help(cmd, &buf); err != nil {
+				t.Fatalf("Help(): failed to write help: %s", err)
+			}
+
+			assertStringsDiff(t, buf.String(), tc.want)
+		})
+	}
+}
+
+func TestDefaultHelper_Help_global(t *testing.T) {
+	app := App{
+		Name: "global",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Bool(cmd, "verbose",
+				WithShort("v"),
+				Usage("Verbose output"),
+				Global,
+			)
+
+			_ = String(cmd, "config",
+				WithShort("c"),
+				Usage("Path to the config"),
+				Global,
+			)
+
+			_ = Int(cmd, "level")
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+		Commands: []Command{
+			{
+				Name: "sub",
+				Action: ActionFunc(func(cmd *Command) ActionRunner {
+					_ = Bool(cmd, "force",
+						WithShort("f"),
+						Usage("Force"),
+					)
+
+					return func(cmd *Command) error { panic("not implemented") }
+				}),
+			},
+		},
+	}
+
+	cmd, err := app.Command("global", "sub")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := (DefaultHelper{}).Help(cmd, &buf); err != nil {
+		t.Fatalf("Help(): failed to write help: %s", err)
+	}
+
+	want := `Usage: global sub [options...]
+
+Options:
+  -f, --force            Force
+
+Inherited options:
+  -v, --verbose          Verbose output
+  -c, --config string    Path to the config
+`
+
+	assertStringsDiff(t, buf.String(), want)
+}
+
+func TestDefaultHelper_Help_aliases(t *testing.T) {
+	app := App{
+		Name: "aliases",
+		Commands: []Command{
+			{
+				Name:    "list",
+				Aliases: []string{"ls"},
+				Usage:   Usage("List items"),
+			},
+			{
+				Name:    "remove",
+				Aliases: []string{"rm"},
+				Usage:   Usage("Remove items"),
+			},
+			{
+				Name:  "clone",
+				Usage: Usage("Clone items"),
+			},
+		},
+	}
+
+	cmd, err := app.Command("aliases")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := (DefaultHelper{}).Help(cmd, &buf); err != nil {
+		t.Fatalf("Help(): failed to write help: %s", err)
+	}
+
+	want := `Usage: aliases
+       aliases [command]
+
+Commands:
+  list, ls      List items
+  remove, rm    Remove items
+  clone         Clone items
+`
+
+	assertStringsDiff(t, buf.String(), want)
+}
+
+func TestDefaultHelper_Help_flag_aliases(t *testing.T) {
+	app := App{
+		Name: "aliases",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Bool(cmd, "dry-run",
+				WithShort("n"),
+				WithAlias("dry"),
+				Usage("Do nothing"),
+			)
+
+			_ = String(cmd, "output",
+				WithShort("o"),
+				WithAlias("O"),
+				WithAlias("out"),
+				Usage("Output file"),
+			)
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+	}
+
+	cmd, err := app.Command("aliases")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := (DefaultHelper{}).Help(cmd, &buf); err != nil {
+		t.Fatalf("Help(): failed to write help: %s", err)
+	}
+
+	want := `Usage: aliases [options...]
+
+Options:
+  -n, --dry-run, --dry              Do nothing
+  -o, -O, --output, --out string    Output file
+`
+
+	assertStringsDiff(t, buf.String(), want)
+}
+
+func TestDefaultHelper_Help_count(t *testing.T) {
+	app := App{
+		Name: "count",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Count(cmd, "verbose",
+				WithShort("v"),
+				Usage("Verbosity level"),
+			)
+
+			_ = Int(cmd, "jobs",
+				WithShort("j"),
+				Usage("Number of jobs"),
+			)
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+	}
+
+	cmd, err := app.Command("count")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := (DefaultHelper{}).Help(cmd, &buf); err != nil {
+		t.Fatalf("Help(): failed to write help: %s", err)
+	}
+
+	want := `Usage: count [options...]
+
+Options:
+  -v, --verbose     Verbosity level
+  -j, --jobs int    Number of jobs
+`
+
+	assertStringsDiff(t, buf.String(), want)
+}
+
+func TestDefaultHelper_Help_optional_value(t *testing.T) {
+	app := App{
+		Name: "optional",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = String(cmd, "color",
+				WithShort("c"),
+				OptionalValue("auto"),
+				Usage("Colorize the output"),
+			)
+
+			_ = String(cmd, "format",
+				OptionalValue("json"),
+				WithValueName("when"),
+				Usage("Output format"),
+			)
+
+			_ = Int(cmd, "jobs",
+				WithShort("j"),
+				WithValueName("n"),
+				Usage("Number of jobs"),
+			)
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+	}
+
+	cmd, err := app.Command("optional")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := (DefaultHelper{}).Help(cmd, &buf); err != nil {
+		t.Fatalf("Help(): failed to write help: %s", err)
+	}
+
+	want := `Usage: optional [options...]
+
+Options:
+  -c, --color[=<string>]    Colorize the output
+      --format[=<when>]     Output format
+  -j, --jobs n              Number of jobs
+`
+
+	assertStringsDiff(t, buf.String(), want)
+}
+
+func TestDefaultHelper_Help_hidden(t *testing.T) {
+	app := App{
+		Name: "hidden",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = StringArg(cmd, "name")
+
+			_ = StringArg(cmd, "secret",
+				Optional,
+				Hidden,
+			)
+
+			_ = Bool(cmd, "verbose",
+				WithShort("V"),
+			)
+
+			_ = Bool(cmd, "debug",
+				WithShort("d"),
+				Hidden,
+			)
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+		Commands: []Command{
+			{
+				Name:  "list",
+				Usage: Usage("List items"),
+			},
+			{
+				Name:   "dump",
+				Usage:  Usage("Dump internal state"),
+				Hidden: true,
+			},
+		},
+		CommandFlags: []CommandFlag{
+			HelpCommandFlag(),
+			HelpAllCommandFlag(),
+		},
+	}
+
+	tt := []struct {
+		name   string
+		helper DefaultHelper
+		want   string
+	}{
+		{
+			name: "default",
+			want: `Usage: hidden [options...] <name>
+       hidden [options...] [command]
+
+Commands:
+  list    List items
+
+Arguments:
+  <name> string
+
+Options:
+  -V, --verbose
+  -h, --help       Show information about a command
+      --help-all   Show information about a command including hidden items
+`,
+		},
+		{
+			name:   "show hidden",
+			helper: DefaultHelper{ShowHidden: true},
+			want: `Usage: hidden [options...] <name> [secret]
+       hidden [options...] [command]
+
+Commands:
+  list    List items
+  dump    Dump internal state
+
+Arguments:
+  <name> string
+  [secret] string
+
+Options:
+  -V, --verbose
+  -d, --debug
+  -h, --help       Show information about a command
+      --help-all   Show information about a command including hidden items
+`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd, err := app.Command("hidden")
+			if err != nil {
+				t.Fatalf("Command(): failed to get command: %s", err)
+			}
+
+			var buf strings.Builder
+			if err := tc.helper.Help(cmd, &buf); err != nil {
+				t.Fatalf("Help(): failed to write help: %s", err)
+			}
+
+			assertStringsDiff(t, buf.String(), tc.want)
+		})
+	}
+}
+
+func TestDefaultHelper_Help_negatable(t *testing.T) {
+	app := App{
+		Name: "negatable",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			color := Bool(cmd, "color",
+				WithShort("c"),
+				Usage("Colorize the output"),
+				Negatable,
+			)
+			*color = true
+
+			_ = Bool(cmd, "quiet",
+				WithShort("q"),
+				Usage("Suppress the output"),
+			)
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+	}
+
+	cmd, err := app.Command("negatable")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := (DefaultHelper{}).Help(cmd, &buf); err != nil {
+		t.Fatalf("Help(): failed to write help: %s", err)
+	}
+
+	want := `Usage: negatable [options...]
+
+Options:
+  -c, --[no-]color    Colorize the output (default: true)
+  -q, --quiet         Suppress the output
+`
+
+	assertStringsDiff(t, buf.String(), want)
+}
+
+func TestDefaultHelper_Help_object(t *testing.T) {
+	var cfg struct {
+		Host string `usage:"Database host"`
+		Pool struct {
+			Size int `usage:"Pool size"`
+		}
+		Labels map[string]interface{}
+	}
+	cfg.Host = "localhost"
+
+	app := App{
+		Name: "object",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Bool(cmd, "verbose",
+				WithShort("V"),
+				Usage("Verbose output"),
+			)
+
+			_ = Object(cmd, "db", &cfg)
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+	}
+
+	cmd, err := app.Command("object")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := (DefaultHelper{}).Help(cmd, &buf); err != nil {
+		t.Fatalf("Help(): failed to write help: %s", err)
+	}
+
+	want := `Usage: object [options...]
+
+Options:
+  -V, --verbose               Verbose output
+
+Options (--db.*):
+      --db.host string        Database host (default: localhost)
+      --db.pool.size int      Pool size
+      --db.labels.<key> object
+`
+
+	assertStringsDiff(t, buf.String(), want)
+}
+
 func assertStringsDiff(t *testing.T, got, want string) {
 	t.Helper()
 
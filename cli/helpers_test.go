@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTempFiles writes files into a temporary directory. "$DIR" in the
+// content is replaced with the directory. The caller must remove the directory.
+func writeTempFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "cli-test")
+	if err != nil {
+		t.Fatalf("TempDir(): failed to create a directory: %s", err)
+	}
+
+	for name, content := range files {
+		content = strings.ReplaceAll(content, "$DIR", dir)
+
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile(): failed to write a file: %s", err)
+		}
+	}
+
+	return dir
+}
@@ -0,0 +1,287 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrMissingPort = errors.New("missing port")
+
+	ErrMissingHost = errors.New("missing host")
+
+	ErrScheme = errors.New("unsupported scheme")
+
+	ErrNotURL = errors.New("not a URL")
+)
+
+// HostPort is a network endpoint in the "host:port" form.
+type HostPort struct {
+	Host string
+	Port uint16
+}
+
+// String returns the endpoint in the "host:port" form, or the "[host]:port"
+// form for IPv6 addresses.
+func (hp HostPort) String() string {
+	if hp.Host == "" && hp.Port == 0 {
+		return ""
+	}
+
+	return net.JoinHostPort(hp.Host, strconv.Itoa(int(hp.Port)))
+}
+
+// parseHostPort parses the "host:port" string. If the port is missing and the
+// default port is not 0, the default port is used.
+func parseHostPort(s string, defaultPort uint16) (HostPort, error) {
+	host, port, err := net.SplitHostPort(s)
+	if err != nil {
+		// The port is missing: "host", "::1" or "[::1]".
+		host = s
+		if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+			host = host[1 : len(host)-1]
+		}
+
+		if host == "" || strings.ContainsAny(host, "[]") || (strings.Contains(host, ":") && net.ParseIP(host) == nil) {
+			return HostPort{}, hostPortError(ErrSyntax)
+		}
+
+		if defaultPort == 0 {
+			return HostPort{}, hostPortError(ErrMissingPort)
+		}
+
+		return HostPort{Host: host, Port: defaultPort}, nil
+	}
+
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return HostPort{}, numError("host:port", err)
+	}
+
+	return HostPort{Host: host, Port: uint16(p)}, nil
+}
+
+func hostPortError(err error) error {
+	return &ParseValueError{
+		Type: "host:port",
+		Err:  err,
+	}
+}
+
+var (
+	_ Value   = (*hostPortValue)(nil)
+	_ Getter  = (*hostPortValue)(nil)
+	_ Emptier = (*hostPortValue)(nil)
+	_ Typer   = (*hostPortValue)(nil)
+)
+
+type hostPortValue struct {
+	p           *HostPort
+	defaultPort uint16
+}
+
+func newHostPortValue(p *HostPort, defaultPort uint16) *hostPortValue {
+	return &hostPortValue{p: p, defaultPort: defaultPort}
+}
+
+func (v *hostPortValue) Set(val string) error {
+	hp, err := parseHostPort(val, v.defaultPort)
+	if err != nil {
+		return err
+	}
+
+	*v.p = hp
+	return nil
+}
+
+func (v *hostPortValue) Get() interface{} { return *v.p }
+
+func (v *hostPortValue) Empty() bool { return *v.p == HostPort{} }
+
+func (v *hostPortValue) String() string { return v.p.String() }
+
+func (*hostPortValue) Type() string { return "host:port" }
+
+var (
+	_ Value      = (*hostPortValues)(nil)
+	_ Getter     = (*hostPortValues)(nil)
+	_ Emptier    = (*hostPortValues)(nil)
+	_ Typer      = (*hostPortValues)(nil)
+	_ sliceValue = (*hostPortValues)(nil)
+)
+
+type hostPortValues struct {
+	p           *[]HostPort
+	defaultPort uint16
+}
+
+func newHostPortValues(p *[]HostPort, defaultPort uint16) *hostPortValues {
+	return &hostPortValues{p: p, defaultPort: defaultPort}
+}
+
+func (vs *hostPortValues) Set(val string) error {
+	for _, s := range strings.Split(val, ",") {
+		if s == "" {
+			continue
+		}
+
+		hp, err := parseHostPort(s, vs.defaultPort)
+		if err != nil {
+			return err
+		}
+
+		*vs.p = append(*vs.p, hp)
+	}
+
+	return nil
+}
+
+func (vs *hostPortValues) Get() interface{} { return *vs.p }
+
+func (vs *hostPortValues) Empty() bool { return len(*vs.p) == 0 }
+
+func (vs *hostPortValues) String() string {
+	values := make([]string, len(*vs.p))
+	for i, hp := range *vs.p {
+		values[i] = hp.String()
+	}
+
+	return strings.Join(values, ",")
+}
+
+func (*hostPortValues) Type() string { return "[]host:port" }
+
+func (*hostPortValues) IsSliceValue() bool { return true }
+
+// EndpointVar defines a host:port flag with specified name. If defaultPort is
+// not 0, the port can be omitted.
+func EndpointVar(register Register, p *HostPort, name string, defaultPort uint16, options ...FlagOptionApplyer) error {
+	return Var(register, newHostPortValue(p, defaultPort), name, options...)
+}
+
+// Endpoint defines a host:port flag with specified name. If defaultPort is
+// not 0, the port can be omitted.
+// The return value is the address of a HostPort variable that stores the value
+// of the flag.
+//
+//   addr := cli.Endpoint(register, "listen", 8080)
+func Endpoint(register Register, name string, defaultPort uint16, options ...FlagOptionApplyer) *HostPort {
+	p := new(HostPort)
+	_ = EndpointVar(register, p, name, defaultPort, options...)
+	return p
+}
+
+// EndpointsVar defines a []host:port flag with specified name. If defaultPort
+// is not 0, ports can be omitted.
+func EndpointsVar(register Register, p *[]HostPort, name string, defaultPort uint16, options ...FlagOptionApplyer) error {
+	return Var(register, newHostPortValues(p, defaultPort), name, options...)
+}
+
+// Endpoints defines a []host:port flag with specified name. If defaultPort is
+// not 0, ports can be omitted.
+// The return value is the address of a []HostPort variable that stores values
+// of the flag.
+//
+//   peers := cli.Endpoints(register, "peer", 7946)
+func Endpoints(register Register, name string, defaultPort uint16, options ...FlagOptionApplyer) *[]HostPort {
+	p := new([]HostPort)
+	_ = EndpointsVar(register, p, name, defaultPort, options...)
+	return p
+}
+
+// EndpointArgVar defines a host:port argument with specified name. If
+// defaultPort is not 0, the port can be omitted.
+func EndpointArgVar(register Register, p *HostPort, name string, defaultPort uint16, options ...ArgOptionApplyer) error {
+	return ArgVar(register, newHostPortValue(p, defaultPort), name, options...)
+}
+
+// EndpointArg defines a host:port argument with specified name. If
+// defaultPort is not 0, the port can be omitted.
+// The return value is the address of a HostPort variable that stores the value
+// of the argument.
+//
+//   addr := cli.EndpointArg(register, "addr", 22)
+func EndpointArg(register Register, name string, defaultPort uint16, options ...ArgOptionApplyer) *HostPort {
+	p := new(HostPort)
+	_ = EndpointArgVar(register, p, name, defaultPort, options...)
+	return p
+}
+
+// RestEndpointsVar defines the []host:port rest arguments with specified
+// name. If defaultPort is not 0, ports can be omitted.
+func RestEndpointsVar(register Register, p *[]HostPort, name string, defaultPort uint16, options ...RestOptionApplyer) error {
+	return RestVar(register, newHostPortValues(p, defaultPort), name, options...)
+}
+
+// RestEndpoints defines the []host:port rest arguments with specified name.
+// If defaultPort is not 0, ports can be omitted.
+// The return value is the address of a []HostPort variable that stores values
+// of arguments.
+//
+//   hosts := cli.RestEndpoints(register, "hosts", 22)
+func RestEndpoints(register Register, name string, defaultPort uint16, options ...RestOptionApplyer) *[]HostPort {
+	p := new([]HostPort)
+	_ = RestEndpointsVar(register, p, name, defaultPort, options...)
+	return p
+}
+
+// hostSchemes are schemes which require a host.
+var hostSchemes = map[string]bool{
+	"http":  true,
+	"https": true,
+	"ws":    true,
+	"wss":   true,
+	"ftp":   true,
+	"ftps":  true,
+	"sftp":  true,
+	"ssh":   true,
+}
+
+// Schemes allows only URLs with one of the schemes. URLs of well-known network
+// schemes, like "http" and "https", must also have a host; "file:///path" and
+// "mailto:user@example.com" don't need it. It can be used only with URL
+// values; other values are reported at the registration.
+//
+//   _ = cli.URL(register, "endpoint", cli.Schemes("http", "https"))
+func Schemes(schemes ...string) Validator {
+	description := "schemes: " + strings.Join(schemes, ", ")
+
+	return Validator{
+		Func: func(v interface{}) error {
+			u, ok := v.(url.URL)
+			if !ok {
+				return ErrNotURL
+			}
+
+			if u.Scheme == "" {
+				return fmt.Errorf("%w: missing scheme: must be one of %s", ErrScheme, strings.Join(schemes, ", "))
+			}
+
+			for _, s := range schemes {
+				if !strings.EqualFold(u.Scheme, s) {
+					continue
+				}
+
+				if u.Host == "" && hostSchemes[strings.ToLower(u.Scheme)] {
+					return ErrMissingHost
+				}
+
+				return nil
+			}
+
+			return fmt.Errorf("%w '%s': must be one of %s", ErrScheme, u.Scheme, strings.Join(schemes, ", "))
+		},
+		Description: description,
+		check: func(v interface{}) error {
+			if _, ok := v.(url.URL); !ok {
+				return ErrNotURL
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,254 @@
+package cli
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParser_Parse_net(t *testing.T) {
+	var (
+		register DefaultRegister
+		parser   DefaultParser
+	)
+
+	ip := IP(&register, "ip")
+	ips := IPs(&register, "dns")
+	subnet := IPNet(&register, "subnet")
+	endpoint := URL(&register, "endpoint", Schemes("http", "https"))
+	file := URL(&register, "file", Schemes("file"))
+	mail := URL(&register, "mail", Schemes("mailto"))
+	listen := Endpoint(&register, "listen", 8080)
+	peers := Endpoints(&register, "peer", 7946)
+	addr := EndpointArg(&register, "addr", 0)
+	hosts := RestEndpoints(&register, "hosts", 22)
+
+	err := parser.Parse(nil, &register, []string{
+		"--ip", "10.0.0.1",
+		"--dns", "1.1.1.1,8.8.8.8",
+		"--subnet", "10.0.0.1/8",
+		"--endpoint", "https://example.com/api",
+		"--file", "file:///etc/hosts",
+		"--mail", "mailto:user@example.com",
+		"--listen", "::1",
+		"--peer", "a:1,b",
+		"localhost:80",
+		"[::1]", "example.com:2222",
+	})
+	if err != nil {
+		t.Fatalf("Parse(): failed to parse args: %s", err)
+	}
+
+	if want := net.ParseIP("10.0.0.1"); !ip.Equal(want) {
+		t.Errorf("Parse(): ip: got = %v, want = %v", *ip, want)
+	}
+
+	if want := []net.IP{net.ParseIP("1.1.1.1"), net.ParseIP("8.8.8.8")}; !reflect.DeepEqual(*ips, want) {
+		t.Errorf("Parse(): dns: got = %v, want = %v", *ips, want)
+	}
+
+	if got, want := subnet.String(), "10.0.0.0/8"; got != want {
+		t.Errorf("Parse(): subnet: got = %q, want = %q", got, want)
+	}
+
+	if got, want := endpoint.String(), "https://example.com/api"; got != want {
+		t.Errorf("Parse(): endpoint: got = %q, want = %q", got, want)
+	}
+
+	if got, want := file.String(), "file:///etc/hosts"; got != want {
+		t.Errorf("Parse(): file: got = %q, want = %q", got, want)
+	}
+
+	if got, want := mail.String(), "mailto:user@example.com"; got != want {
+		t.Errorf("Parse(): mail: got = %q, want = %q", got, want)
+	}
+
+	if want := (HostPort{Host: "::1", Port: 8080}); *listen != want {
+		t.Errorf("Parse(): listen: got = %v, want = %v", *listen, want)
+	}
+
+	if want := []HostPort{{"a", 1}, {"b", 7946}}; !reflect.DeepEqual(*peers, want) {
+		t.Errorf("Parse(): peer: got = %v, want = %v", *peers, want)
+	}
+
+	if want := (HostPort{Host: "localhost", Port: 80}); *addr != want {
+		t.Errorf("Parse(): addr: got = %v, want = %v", *addr, want)
+	}
+
+	if want := []HostPort{{"::1", 22}, {"example.com", 2222}}; !reflect.DeepEqual(*hosts, want) {
+		t.Errorf("Parse(): hosts: got = %v, want = %v", *hosts, want)
+	}
+}
+
+func TestParser_Parse_net_errors(t *testing.T) {
+	tt := []struct {
+		name string
+		args []string
+		want error
+	}{
+		{
+			name: "ip",
+			args: []string{"--ip", "10.0.0.256", "a:1"},
+			want: &FlagError{Long: "ip", Err: &ParseValueError{Type: "net.IP", Err: ErrSyntax}},
+		},
+		{
+			name: "cidr",
+			args: []string{"--subnet", "10.0.0.1", "a:1"},
+			want: &FlagError{Long: "subnet", Err: &ParseValueError{Type: "net.IPNet", Err: ErrSyntax}},
+		},
+		{
+			name: "url",
+			args: []string{"--endpoint", "http://[::1", "a:1"},
+			want: &FlagError{Long: "endpoint", Err: &ParseValueError{Type: "url.URL", Err: ErrSyntax}},
+		},
+		{
+			name: "scheme",
+			args: []string{"--endpoint", "ftp://example.com", "a:1"},
+			want: &FlagError{Long: "endpoint", Err: &ParseValueError{Type: "url.URL", Err: ErrScheme}},
+		},
+		{
+			name: "missing scheme",
+			args: []string{"--endpoint", "example.com/path", "a:1"},
+			want: &FlagError{Long: "endpoint", Err: &ParseValueError{Type: "url.URL", Err: ErrScheme}},
+		},
+		{
+			name: "missing host",
+			args: []string{"--endpoint", "http:example.com", "a:1"},
+			want: &FlagError{Long: "endpoint", Err: &ParseValueError{Type: "url.URL", Err: ErrMissingHost}},
+		},
+		{
+			name: "relative url",
+			args: []string{"--file", "path/to/file", "a:1"},
+			want: &FlagError{Long: "file", Err: &ParseValueError{Type: "url.URL", Err: ErrScheme}},
+		},
+		{
+			name: "unsupported hostless scheme",
+			args: []string{"--file", "http:///path", "a:1"},
+			want: &FlagError{Long: "file", Err: &ParseValueError{Type: "url.URL", Err: ErrScheme}},
+		},
+		{
+			name: "missing port",
+			args: []string{"localhost"},
+			want: &ArgError{Name: "addr", Err: &ParseValueError{Type: "host:port", Err: ErrMissingPort}},
+		},
+		{
+			name: "port out of range",
+			args: []string{"localhost:65536"},
+			want: &ArgError{Name: "addr", Err: &ParseValueError{Type: "host:port", Err: ErrRange}},
+		},
+		{
+			name: "invalid endpoint",
+			args: []string{"a:1", "a:b:c"},
+			want: &ArgError{Name: "hosts", Index: 1, Err: &ParseValueError{Type: "host:port", Err: ErrSyntax}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			_ = IP(&register, "ip")
+			_ = IPNet(&register, "subnet")
+			_ = URL(&register, "endpoint", Schemes("http", "https"))
+			_ = URL(&register, "file", Schemes("file", "mailto"))
+			_ = EndpointArg(&register, "addr", 0)
+			_ = RestEndpoints(&register, "hosts", 22)
+
+			if err := parser.Parse(nil, &register, tc.args); !errors.Is(err, tc.want) {
+				t.Errorf("Parse(): got error = %q, want error = %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestRegister_schemes_not_url(t *testing.T) {
+	tt := []struct {
+		name     string
+		register func(r Register)
+		want     error
+	}{
+		{
+			name:     "flag",
+			register: func(r Register) { _ = String(r, "name", Schemes("http")) },
+			want:     &FlagError{Long: "name", Err: ErrNotURL},
+		},
+		{
+			name:     "arg",
+			register: func(r Register) { _ = StringArg(r, "name", Schemes("http")) },
+			want:     &ArgError{Name: "name", Err: ErrNotURL},
+		},
+		{
+			name:     "url",
+			register: func(r Register) { _ = URL(r, "endpoint", Schemes("http")) },
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var register DefaultRegister
+
+			tc.register(&register)
+
+			if err := register.Err(); !errors.Is(err, tc.want) {
+				t.Errorf("Err(): got error = %q, want error = %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestHostPort_String(t *testing.T) {
+	tt := []struct {
+		hp   HostPort
+		want string
+	}{
+		{HostPort{}, ""},
+		{HostPort{Host: "localhost", Port: 80}, "localhost:80"},
+		{HostPort{Host: "::1", Port: 22}, "[::1]:22"},
+		{HostPort{Port: 8080}, ":8080"},
+	}
+
+	for _, tc := range tt {
+		if got := tc.hp.String(); got != tc.want {
+			t.Errorf("String(): got = %q, want = %q", got, tc.want)
+		}
+	}
+}
+
+func TestDefaultHelper_Help_net(t *testing.T) {
+	app := App{
+		Name: "net",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = IP(cmd, "ip")
+			_ = IPNet(cmd, "subnet")
+			_ = URL(cmd, "endpoint", Schemes("http", "https"))
+			_ = Endpoint(cmd, "listen", 8080)
+			_ = EndpointArg(cmd, "addr", 22)
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+	}
+
+	cmd, err := app.Command("net")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := (DefaultHelper{}).Help(cmd, &buf); err != nil {
+		t.Fatalf("Help(): failed to write help: %s", err)
+	}
+
+	assertContains(t, buf.String(),
+		"<addr> host:port",
+		"--ip net.IP",
+		"--subnet net.IPNet",
+		"--endpoint url.URL",
+		"(schemes: http, https)",
+		"--listen host:port",
+	)
+}
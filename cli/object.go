@@ -0,0 +1,381 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrNotObject = errors.New("not a struct or map[string]interface{}")
+
+	ErrUnsupportedType = errors.New("unsupported type")
+
+	ErrObjectNotSupported = errors.New("objects are not supported")
+)
+
+// Object defines flags for every field of the struct (or a map) pointed by p
+// with dotted names: "--db.host", "--db.pool.size".
+//
+//   var cfg struct {
+//       Host string `usage:"Database host"`
+//       Pool struct {
+//           Size int
+//       }
+//   }
+//
+//   _ = cli.Object(register, "db", &cfg)
+//
+// A name of a flag may be changed with the "cli" tag and the field may be
+// skipped with `cli:"-"`. By default names are fields names in kebab-case.
+// A usage may be set with the "usage" tag.
+//
+// A map[string]interface{} accepts any keys. Values are stored as strings and
+// nested keys are stored in nested maps.
+//
+// Options are applied to all flags of the object. Either all flags are
+// registered or none of them. Errors are also returned by Err() of the
+// register.
+func Object(register Register, name string, p interface{}, options ...FlagOptionApplyer) error {
+	or, ok := register.(ObjectRegister)
+	if !ok {
+		return &FlagError{
+			Long: name,
+			Err:  ErrObjectNotSupported,
+		}
+	}
+
+	return or.RegisterObject(name, p, options...)
+}
+
+// ObjectRegister is an optional interface of a Register. It registers all
+// flags of the object or none of them.
+type ObjectRegister interface {
+	RegisterObject(name string, p interface{}, options ...FlagOptionApplyer) error
+}
+
+func (r *DefaultRegister) RegisterObject(name string, p interface{}, options ...FlagOptionApplyer) (err error) {
+	defer func() {
+		if err != nil && r.registerObjectErr == nil {
+			r.registerObjectErr = err
+		}
+	}()
+
+	// The whole object is checked before registration.
+	flags, err := objectFlags(name, p, options)
+	if err != nil {
+		return err
+	}
+
+	n := len(r.flags.data)
+	for _, flag := range flags {
+		if err := r.RegisterFlag(flag); err != nil {
+			r.flags.truncate(n)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Command) RegisterObject(name string, p interface{}, options ...FlagOptionApplyer) error {
+	or, ok := c.register.(ObjectRegister)
+	if !ok {
+		err := &FlagError{
+			Long: name,
+			Err:  ErrObjectNotSupported,
+		}
+
+		// The register can't record the error, so it's returned by Err().
+		if c.err == nil {
+			c.err = err
+		}
+
+		return err
+	}
+
+	return or.RegisterObject(name, p, options...)
+}
+
+// objectFlags returns flags for every field of the struct (or a map) pointed
+// by p.
+func objectFlags(name string, p interface{}, options []FlagOptionApplyer) ([]Flag, error) {
+	v := reflect.ValueOf(p)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil, &FlagError{
+			Long: name,
+			Err:  ErrNotObject,
+		}
+	}
+
+	if _, ok := p.(*map[string]interface{}); !ok && v.Elem().Kind() != reflect.Struct {
+		return nil, &FlagError{
+			Long: name,
+			Err:  ErrNotObject,
+		}
+	}
+
+	var flags []Flag
+	err := walkObject(name, name, v.Elem(), "", nil, nil, options, func(flag Flag) {
+		flags = append(flags, flag)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return flags, nil
+}
+
+// walkObject calls add with flags for the value. attach is called when a flag
+// is set and stores structs allocated for nil pointers into their parents.
+// path holds struct types of the current branch to detect recursive types.
+func walkObject(object, name string, v reflect.Value, usage string, attach func(), path map[reflect.Type]bool, options []FlagOptionApplyer, add func(Flag)) error {
+	// Dynamic keys.
+	if m, ok := v.Addr().Interface().(*map[string]interface{}); ok {
+		add(newObjectFlag(object, newObjectValue(m), name, usage, attach, options))
+		return nil
+	}
+	// Recursive types have infinite flags.
+	if t := v.Type(); t.Kind() == reflect.Ptr && path[t.Elem()] {
+		return &FlagError{
+			Long: name,
+			Err:  ErrUnsupportedType,
+		}
+	}
+
+	if v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.Struct {
+		// Nil pointers are not changed until a flag of the struct is set.
+		if v.IsNil() {
+			field, parent := v, attach
+			ptr := reflect.New(v.Type().Elem())
+
+			attach = func() {
+				if parent != nil {
+					parent()
+				}
+
+				if field.IsNil() {
+					field.Set(ptr)
+				}
+			}
+
+			v = ptr
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		if value, ok := newValueOf(v.Addr().Interface()); ok {
+			add(newObjectFlag(object, value, name, usage, attach, options))
+			return nil
+		}
+
+		return &FlagError{
+			Long: name,
+			Err:  ErrUnsupportedType,
+		}
+	}
+
+	t := v.Type()
+
+	if path == nil {
+		path = make(map[reflect.Type]bool)
+	}
+
+	path[t] = true
+	defer delete(path, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		// Skip unexported fields.
+		if field.PkgPath != "" {
+			continue
+		}
+
+		fieldName := field.Tag.Get("cli")
+		if fieldName == "-" {
+			continue
+		}
+
+		if fieldName == "" {
+			fieldName = kebabCase(field.Name)
+		}
+
+		err := walkObject(object, name+"."+fieldName, v.Field(i),
+			field.Tag.Get("usage"), attach, path, options, add,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newObjectFlag(object string, value Value, name, usage string, attach func(), options []FlagOptionApplyer) Flag {
+	var opts FlagOptions
+	opts.Long = name
+
+	// Explicit options override the usage from the tag.
+	if usage != "" {
+		Usage(usage).FlagOptionApply(&opts)
+	}
+
+	opts.applyFlagOptions(options)
+	opts.object = object
+
+	flag := newFlag(value, opts)
+	flag.onSet = attach
+
+	return flag
+}
+
+// kebabCase converts Go names into flag names: "PoolSize" -> "pool-size",
+// "DBHost" -> "db-host".
+func kebabCase(name string) string {
+	var buf strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+
+		isUpper := c >= 'A' && c <= 'Z'
+		if isUpper && i > 0 {
+			prevLower := name[i-1] >= 'a' && name[i-1] <= 'z' || name[i-1] >= '0' && name[i-1] <= '9'
+			nextLower := i+1 < len(name) && name[i+1] >= 'a' && name[i+1] <= 'z'
+			prevUpper := name[i-1] >= 'A' && name[i-1] <= 'Z'
+
+			if prevLower || (prevUpper && nextLower) {
+				_ = buf.WriteByte('-')
+			}
+		}
+
+		if isUpper {
+			c = c - 'A' + 'a'
+		}
+
+		_ = buf.WriteByte(c)
+	}
+
+	return buf.String()
+}
+
+type objectFlag interface {
+	Value
+	SetKey(key, value string) error
+	IsObjectFlag() bool
+}
+
+var (
+	_ Value      = (*objectValue)(nil)
+	_ Getter     = (*objectValue)(nil)
+	_ Emptier    = (*objectValue)(nil)
+	_ Typer      = (*objectValue)(nil)
+	_ objectFlag = (*objectValue)(nil)
+)
+
+type objectValue struct {
+	m *map[string]interface{}
+}
+
+func newObjectValue(p *map[string]interface{}) *objectValue {
+	return &objectValue{m: p}
+}
+
+// Set sets a value in the "key=value" form.
+func (o *objectValue) Set(s string) error {
+	idx := strings.IndexByte(s, '=')
+	if idx < 1 {
+		return &ParseValueError{
+			Type: "object",
+			Err:  ErrSyntax,
+		}
+	}
+
+	return o.SetKey(s[:idx], s[idx+1:])
+}
+
+func (o *objectValue) SetKey(key, value string) error {
+	if *o.m == nil {
+		*o.m = make(map[string]interface{})
+	}
+
+	m := *o.m
+	for {
+		idx := strings.IndexByte(key, '.')
+		if idx == -1 {
+			break
+		}
+
+		k := key[:idx]
+		key = key[idx+1:]
+
+		if k == "" {
+			return &ParseValueError{
+				Type: "object",
+				Err:  ErrSyntax,
+			}
+		}
+
+		switch next := m[k].(type) {
+		case map[string]interface{}:
+			m = next
+
+		case nil:
+			nm := make(map[string]interface{})
+			m[k] = nm
+			m = nm
+
+		default:
+			return &ParseValueError{
+				Type: "object",
+				Err:  ErrSyntax,
+			}
+		}
+	}
+
+	if key == "" {
+		return &ParseValueError{
+			Type: "object",
+			Err:  ErrSyntax,
+		}
+	}
+
+	m[key] = value
+
+	return nil
+}
+
+func (o *objectValue) IsObjectFlag() bool { return true }
+
+func (o *objectValue) Get() interface{} { return *o.m }
+
+func (o *objectValue) Empty() bool { return len(*o.m) == 0 }
+
+func (o *objectValue) String() string {
+	if o.m == nil || len(*o.m) == 0 {
+		return ""
+	}
+
+	var pairs []string
+	var walk func(prefix string, m map[string]interface{})
+	walk = func(prefix string, m map[string]interface{}) {
+		for k, v := range m {
+			if next, ok := v.(map[string]interface{}); ok {
+				walk(prefix+k+".", next)
+				continue
+			}
+
+			pairs = append(pairs, prefix+k+"="+fmt.Sprint(v))
+		}
+	}
+	walk("", *o.m)
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+func (*objectValue) Type() string { return "object" }
@@ -0,0 +1,261 @@
+package cli
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testDBConfig struct {
+	Host    string `usage:"Database host"`
+	Port    int
+	Timeout time.Duration
+	Tags    []string
+	Pool    struct {
+		MaxSize int `cli:"size"`
+	}
+	TLS *struct {
+		Enabled bool
+	}
+	Extra   map[string]interface{}
+	Ignored string `cli:"-"`
+	private string
+}
+
+func TestObject(t *testing.T) {
+	var (
+		register DefaultRegister
+		parser   DefaultParser
+	)
+
+	var cfg testDBConfig
+	cfg.Host = "localhost"
+
+	if err := Object(&register, "db", &cfg); err != nil {
+		t.Fatalf("Object(): failed to register: %s", err)
+	}
+
+	args := []string{
+		"--db.port", "5432",
+		"--db.timeout=5s",
+		"--db.tags", "a,b",
+		"--db.pool.size", "10",
+		"--db.tls.enabled",
+		"--db.extra.region", "eu",
+		"--db.extra.replica.zone", "b",
+	}
+
+	if err := parser.Parse(nil, &register, args); err != nil {
+		t.Fatalf("Parse(): failed to parse args: %s", err)
+	}
+
+	want := testDBConfig{
+		Host:    "localhost",
+		Port:    5432,
+		Timeout: 5 * time.Second,
+		Tags:    []string{"a", "b"},
+		Extra: map[string]interface{}{
+			"region": "eu",
+			"replica": map[string]interface{}{
+				"zone": "b",
+			},
+		},
+	}
+	want.Pool.MaxSize = 10
+	want.TLS = &struct{ Enabled bool }{Enabled: true}
+
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Parse(): cfg: got = %#v, want = %#v", cfg, want)
+	}
+
+	if _, ok := register.LongFlag("db.ignored"); ok {
+		t.Errorf("LongFlag(): db.ignored: got = %v, want = %v", ok, false)
+	}
+}
+
+func TestObject_nil_pointer(t *testing.T) {
+	var (
+		register DefaultRegister
+		parser   DefaultParser
+	)
+
+	var cfg testDBConfig
+
+	if err := Object(&register, "db", &cfg); err != nil {
+		t.Fatalf("Object(): failed to register: %s", err)
+	}
+
+	if cfg.TLS != nil {
+		t.Errorf("Object(): tls: got = %#v, want = nil", cfg.TLS)
+	}
+
+	if err := parser.Parse(nil, &register, []string{"--db.port", "5432"}); err != nil {
+		t.Fatalf("Parse(): failed to parse args: %s", err)
+	}
+
+	if cfg.TLS != nil {
+		t.Errorf("Parse(): tls: got = %#v, want = nil", cfg.TLS)
+	}
+}
+
+func TestObject_usage(t *testing.T) {
+	var register DefaultRegister
+
+	var cfg testDBConfig
+
+	if err := Object(&register, "db", &cfg, WithUsage(Usage("Common usage"))); err != nil {
+		t.Fatalf("Object(): failed to register: %s", err)
+	}
+
+	for _, name := range []string{"db.host", "db.port"} {
+		flag, ok := register.LongFlag(name)
+		if !ok {
+			t.Fatalf("LongFlag(%q): flag not found", name)
+		}
+
+		if flag.Usage != Usage("Common usage") {
+			t.Errorf("Object(): %s: usage: got = %#v, want = %#v", name, flag.Usage, Usage("Common usage"))
+		}
+	}
+}
+
+func TestObject_map(t *testing.T) {
+	var (
+		register DefaultRegister
+		parser   DefaultParser
+	)
+
+	var labels map[string]interface{}
+	if err := Object(&register, "labels", &labels); err != nil {
+		t.Fatalf("Object(): failed to register: %s", err)
+	}
+
+	args := []string{"--labels.app", "nice", "--labels=env=prod", "--labels.team.name", "cli"}
+
+	if err := parser.Parse(nil, &register, args); err != nil {
+		t.Fatalf("Parse(): failed to parse args: %s", err)
+	}
+
+	want := map[string]interface{}{
+		"app": "nice",
+		"env": "prod",
+		"team": map[string]interface{}{
+			"name": "cli",
+		},
+	}
+
+	if !reflect.DeepEqual(labels, want) {
+		t.Errorf("Parse(): labels: got = %#v, want = %#v", labels, want)
+	}
+}
+
+func TestObject_unknown_key(t *testing.T) {
+	var (
+		register DefaultRegister
+		parser   DefaultParser
+	)
+
+	var cfg testDBConfig
+	_ = Object(&register, "db", &cfg)
+
+	got := parser.Parse(nil, &register, []string{"--db.unknown", "1"})
+	want := &ParseFlagError{Name: "--db.unknown", Err: ErrUnknown}
+	if !errors.Is(got, want) {
+		t.Fatalf("Parse(): got error = %q, want error = %q", got, want)
+	}
+}
+
+func TestObject_invalid(t *testing.T) {
+	var register DefaultRegister
+
+	got := Object(&register, "db", new(int))
+	want := &FlagError{Long: "db", Err: ErrNotObject}
+	if !errors.Is(got, want) {
+		t.Fatalf("Object(): got error = %q, want error = %q", got, want)
+	}
+
+	if err := register.Err(); !errors.Is(err, want) {
+		t.Fatalf("Err(): got error = %q, want error = %q", err, want)
+	}
+
+	var cfg struct {
+		Name string
+		Fn   func()
+	}
+	got = Object(&register, "cfg", &cfg)
+	want = &FlagError{Long: "cfg.fn", Err: ErrUnsupportedType}
+	if !errors.Is(got, want) {
+		t.Fatalf("Object(): got error = %q, want error = %q", got, want)
+	}
+
+	if _, ok := register.LongFlag("cfg.name"); ok {
+		t.Errorf("LongFlag(%q): flag of the invalid object is registered", "cfg.name")
+	}
+}
+
+func TestObject_duplicate(t *testing.T) {
+	var register DefaultRegister
+
+	_ = String(&register, "db.port")
+
+	var cfg testDBConfig
+	got := Object(&register, "db", &cfg)
+	want := &FlagError{Long: "db.port", Err: ErrDuplicate}
+	if !errors.Is(got, want) {
+		t.Fatalf("Object(): got error = %q, want error = %q", got, want)
+	}
+
+	if err := register.Err(); !errors.Is(err, want) {
+		t.Fatalf("Err(): got error = %q, want error = %q", err, want)
+	}
+
+	if _, ok := register.LongFlag("db.host"); ok {
+		t.Errorf("LongFlag(%q): flag of the invalid object is registered", "db.host")
+	}
+
+	if flags := register.Flags(); len(flags) != 1 {
+		t.Errorf("Flags(): got %d flags, want 1", len(flags))
+	}
+}
+
+func TestObject_recursive(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+
+	var register DefaultRegister
+
+	got := Object(&register, "n", &node{})
+	want := &FlagError{Long: "n.next", Err: ErrUnsupportedType}
+	if !errors.Is(got, want) {
+		t.Fatalf("Object(): got error = %q, want error = %q", got, want)
+	}
+
+	if err := register.Err(); !errors.Is(err, want) {
+		t.Fatalf("Err(): got error = %q, want error = %q", err, want)
+	}
+}
+
+func TestKebabCase(t *testing.T) {
+	tt := []struct {
+		name string
+		want string
+	}{
+		{name: "Host", want: "host"},
+		{name: "PoolSize", want: "pool-size"},
+		{name: "DBHost", want: "db-host"},
+		{name: "TLS", want: "tls"},
+		{name: "Port2", want: "port2"},
+		{name: "HTTP2Server", want: "http2-server"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := kebabCase(tc.name); got != tc.want {
+				t.Errorf("kebabCase(%q): got = %q, want = %q", tc.name, got, tc.want)
+			}
+		})
+	}
+}
@@ -38,12 +38,25 @@ func (fn RestOptionFunc) RestOptionApply(o *RestOptions) {
 	fn(o)
 }
 
+type PassthroughOptionApplyer interface {
+	PassthroughOptionApply(*PassthroughOptions)
+}
+
+var _ PassthroughOptionApplyer = (PassthroughOptionFunc)(nil)
+
+type PassthroughOptionFunc func(*PassthroughOptions)
+
+func (fn PassthroughOptionFunc) PassthroughOptionApply(o *PassthroughOptions) {
+	fn(o)
+}
+
 // Common options.
 
 var (
-	_ FlagOptionApplyer = NoopOption{}
-	_ ArgOptionApplyer  = NoopOption{}
-	_ RestOptionApplyer = NoopOption{}
+	_ FlagOptionApplyer        = NoopOption{}
+	_ ArgOptionApplyer         = NoopOption{}
+	_ RestOptionApplyer        = NoopOption{}
+	_ PassthroughOptionApplyer = NoopOption{}
 )
 
 type NoopOption struct{}
@@ -52,6 +65,8 @@ func (opt NoopOption) FlagOptionApply(o *FlagOptions) {}
 func (opt NoopOption) ArgOptionApply(o *ArgOptions)   {}
 func (opt NoopOption) RestOptionApply(o *RestOptions) {}
 
+func (opt NoopOption) PassthroughOptionApply(o *PassthroughOptions) {}
+
 func WithNoop() NoopOption {
 	return NoopOption{}
 }
@@ -77,12 +92,107 @@ func (opt Necessary) ArgOptionApply(o *ArgOptions) {
 	o.Necessary = opt
 }
 
+var (
+	_ FlagOptionApplyer = HiddenOption(false)
+	_ ArgOptionApplyer  = HiddenOption(false)
+)
+
+// HiddenOption hides a flag or an argument from the help and completion
+// scripts. Hidden items are still parsed as usual.
+type HiddenOption bool
+
+const Hidden HiddenOption = true
+
+func (opt HiddenOption) FlagOptionApply(o *FlagOptions) {
+	o.Hidden = bool(opt)
+}
+
+func (opt HiddenOption) ArgOptionApply(o *ArgOptions) {
+	o.Hidden = bool(opt)
+}
+
+var _ FlagOptionApplyer = NegatableOption(false)
+
+// NegatableOption adds the "--no-<long>" form to a bool flag which sets the
+// flag to false.
+type NegatableOption bool
+
+const Negatable NegatableOption = true
+
+func (opt NegatableOption) FlagOptionApply(o *FlagOptions) {
+	o.Negatable = bool(opt)
+}
+
+var _ FlagOptionApplyer = FromFileOption(false)
+
+// FromFileOption allows to read a value of a flag from a file with the
+// "--<long>-file path" form or with the "@" prefix: "--<long>=@path". The
+// trailing newline is trimmed. "@@" escapes a value starting with "@".
+// Values from the environment and config files support the "@path" form too.
+//
+// With DefaultParser.ResponseFiles, a standalone "@path" argument is a
+// response file, so use the "--<long>=@path" form.
+//
+//   _ = cli.String(register, "token", cli.FromFile, cli.Sensitive)
+type FromFileOption bool
+
+const FromFile FromFileOption = true
+
+func (opt FromFileOption) FlagOptionApply(o *FlagOptions) {
+	o.FromFile = bool(opt)
+}
+
+var _ FlagOptionApplyer = SensitiveOption(false)
+
+// SensitiveOption masks a value of a flag (e.g. a password or a token) in the
+// help, errors and Flag.DisplayValue.
+type SensitiveOption bool
+
+const Sensitive SensitiveOption = true
+
+func (opt SensitiveOption) FlagOptionApply(o *FlagOptions) {
+	o.Sensitive = bool(opt)
+}
+
+var (
+	_ FlagOptionApplyer = Deprecation{}
+	_ ArgOptionApplyer  = Deprecation{}
+)
+
+// Deprecation marks a flag, an argument or a command as deprecated. The parser
+// prints a warning when a deprecated item is used and forwards its value to
+// the Replacement (if set).
+type Deprecation struct {
+	Message     string
+	Replacement string // Name of a flag, an argument or a command.
+}
+
+// Deprecated returns a deprecation option with the message.
+//
+//   _ = cli.Int(register, "threads", cli.Deprecated("it will be removed in v2"))
+//
+// To forward values to another flag or argument, set a Replacement.
+//
+//   _ = cli.Int(register, "threads", cli.Deprecation{Replacement: "jobs"})
+func Deprecated(message string) Deprecation {
+	return Deprecation{Message: message}
+}
+
+func (d Deprecation) FlagOptionApply(o *FlagOptions) {
+	o.Deprecation = &d
+}
+
+func (d Deprecation) ArgOptionApply(o *ArgOptions) {
+	o.Deprecation = &d
+}
+
 // Usage option.
 
 var (
-	_ FlagOptionApplyer = (UsagerFunc)(nil)
-	_ ArgOptionApplyer  = (UsagerFunc)(nil)
-	_ RestOptionApplyer = (UsagerFunc)(nil)
+	_ FlagOptionApplyer        = (UsagerFunc)(nil)
+	_ ArgOptionApplyer         = (UsagerFunc)(nil)
+	_ RestOptionApplyer        = (UsagerFunc)(nil)
+	_ PassthroughOptionApplyer = (UsagerFunc)(nil)
 )
 
 func (fn UsagerFunc) FlagOptionApply(o *FlagOptions) {
@@ -103,10 +213,17 @@ func (fn UsagerFunc) RestOptionApply(o *RestOptions) {
 	}
 }
 
+func (fn UsagerFunc) PassthroughOptionApply(o *PassthroughOptions) {
+	if fn != nil {
+		o.Usage = fn
+	}
+}
+
 var (
-	_ FlagOptionApplyer = Usage("")
-	_ ArgOptionApplyer  = Usage("")
-	_ RestOptionApplyer = Usage("")
+	_ FlagOptionApplyer        = Usage("")
+	_ ArgOptionApplyer         = Usage("")
+	_ RestOptionApplyer        = Usage("")
+	_ PassthroughOptionApplyer = Usage("")
 )
 
 func (s Usage) FlagOptionApply(o *FlagOptions) {
@@ -127,10 +244,17 @@ func (s Usage) RestOptionApply(o *RestOptions) {
 	}
 }
 
+func (s Usage) PassthroughOptionApply(o *PassthroughOptions) {
+	if s != "" {
+		o.Usage = s
+	}
+}
+
 var (
-	_ FlagOptionApplyer = usager{}
-	_ ArgOptionApplyer  = usager{}
-	_ RestOptionApplyer = usager{}
+	_ FlagOptionApplyer        = usager{}
+	_ ArgOptionApplyer         = usager{}
+	_ RestOptionApplyer        = usager{}
+	_ PassthroughOptionApplyer = usager{}
 )
 
 type usager struct{ usager Usager }
@@ -153,10 +277,17 @@ func (u usager) RestOptionApply(o *RestOptions) {
 	}
 }
 
+func (u usager) PassthroughOptionApply(o *PassthroughOptions) {
+	if u.usager != nil {
+		o.Usage = u.usager
+	}
+}
+
 type UsageOption interface {
 	FlagOptionApplyer
 	ArgOptionApplyer
 	RestOptionApplyer
+	PassthroughOptionApplyer
 }
 
 func WithUsage(u Usager) UsageOption {
@@ -171,12 +302,30 @@ type FlagOptions struct {
 	Value     Value
 	Short     string
 	Long      string
+	Aliases   []string // Additional short and long names.
 	Usage     Usager
 	Necessary Necessary // Optional if unset
+	Env       string    // Environment variable with a value for the flag.
+	Hidden    bool
+	Negatable bool // Add the "--no-<long>" form. Only for bool flags.
+	FromFile  bool // Read the value from a file: "--<long>-file path" or "--<long>=@path".
+	Sensitive bool // Mask the value in the help and errors.
+	Global    bool // Inherit the flag by all subcommands.
 
-	commandFlag bool
+	// Value of the flag without "=value". The flag never takes the next
+	// argument as a value if it's set.
+	ImplicitValue *string
+
+	// Name of the value in the help and completions instead of the type:
+	// "--color[=<when>]".
+	ValueName string
+
+	Deprecation *Deprecation
 
-	// Global bool // TODO
+	Validators []Validator // Checks of the value after it's set.
+
+	commandFlag bool
+	object      string // Name of an object the flag belongs to.
 }
 
 func (o FlagOptions) FlagOptionApply(opts *FlagOptions) {
@@ -188,6 +337,8 @@ func (o FlagOptions) FlagOptionApply(opts *FlagOptions) {
 		opts.Long = o.Long
 	}
 
+	opts.Aliases = append(opts.Aliases, o.Aliases...)
+
 	if o.Usage != nil {
 		opts.Usage = o.Usage
 	}
@@ -198,7 +349,39 @@ func (o FlagOptions) FlagOptionApply(opts *FlagOptions) {
 
 	opts.Necessary = o.Necessary
 
+	if o.Env != "" {
+		opts.Env = o.Env
+	}
+
+	opts.Hidden = o.Hidden
+
+	opts.Negatable = o.Negatable
+
+	opts.FromFile = o.FromFile
+
+	opts.Sensitive = o.Sensitive
+
+	opts.Global = o.Global
+
+	if o.ImplicitValue != nil {
+		opts.ImplicitValue = o.ImplicitValue
+	}
+
+	if o.ValueName != "" {
+		opts.ValueName = o.ValueName
+	}
+
+	if o.Deprecation != nil {
+		opts.Deprecation = o.Deprecation
+	}
+
+	opts.Validators = append(opts.Validators, o.Validators...)
+
 	opts.commandFlag = o.commandFlag
+
+	if o.object != "" {
+		opts.object = o.object
+	}
 }
 
 func (o *FlagOptions) applyName(name string) {
@@ -230,13 +413,54 @@ func WithLong(name string) FlagOptionFunc {
 	}
 }
 
-// var _ FlagOptionApplyer = Global(false)
+// WithAlias adds an additional name to the flag: a short name if the name has
+// a single character or a long name otherwise.
 //
-// type Global bool
+//   _ = cli.Bool(register, "dry-run", cli.WithAlias("dry"), cli.WithAlias("n"))
+func WithAlias(name string) FlagOptionFunc {
+	return func(o *FlagOptions) {
+		o.Aliases = append(o.Aliases, name)
+	}
+}
+
+// OptionalValue makes the value of the flag optional: "--color" is the same as
+// "--color=auto".
 //
-// func (g Global) FlagOptionApply(o *FlagOptions) {
-// 	o.Global = bool(g)
-// }
+//   _ = cli.String(register, "color", cli.OptionalValue("auto"))
+func OptionalValue(value string) FlagOptionFunc {
+	return func(o *FlagOptions) {
+		o.ImplicitValue = &value
+	}
+}
+
+// WithValueName sets the name of the value in the help and completions.
+//
+//   _ = cli.String(register, "color", cli.OptionalValue("auto"), cli.WithValueName("when"))
+func WithValueName(name string) FlagOptionFunc {
+	return func(o *FlagOptions) {
+		o.ValueName = name
+	}
+}
+
+// WithEnv binds the flag to the environment variable. The variable is used
+// only if the flag was not set in the command line.
+func WithEnv(name string) FlagOptionFunc {
+	return func(o *FlagOptions) {
+		o.Env = name
+	}
+}
+
+var _ FlagOptionApplyer = GlobalOption(false)
+
+// GlobalOption makes the flag available in all subcommands of the command:
+// "app --verbose sub" and "app sub --verbose" set the same value.
+type GlobalOption bool
+
+const Global GlobalOption = true
+
+func (opt GlobalOption) FlagOptionApply(o *FlagOptions) {
+	o.Global = bool(opt)
+}
 
 type commandFlag bool
 
@@ -257,6 +481,12 @@ type ArgOptions struct {
 	//     Usually when we use args in our CLIs they are required by default.
 	//     So yes, it's a little bit counfusing (why it isn't Optional?) but
 	//     it makes writing CLIs simpler with default options.
+
+	Hidden bool
+
+	Deprecation *Deprecation
+
+	Validators []Validator // Checks of the value after it's set.
 }
 
 func (o ArgOptions) ArgOptionApply(opts *ArgOptions) {
@@ -269,6 +499,14 @@ func (o ArgOptions) ArgOptionApply(opts *ArgOptions) {
 	}
 
 	opts.Necessary = o.Necessary
+
+	opts.Hidden = o.Hidden
+
+	if o.Deprecation != nil {
+		opts.Deprecation = o.Deprecation
+	}
+
+	opts.Validators = append(opts.Validators, o.Validators...)
 }
 
 func (o *ArgOptions) applyName(name string) {
@@ -290,6 +528,8 @@ var _ RestOptionApplyer = RestOptions{}
 type RestOptions struct {
 	Name  string
 	Usage Usager
+
+	Validators []Validator // Checks of every value after it's set.
 }
 
 func (o RestOptions) RestOptionApply(opts *RestOptions) {
@@ -300,6 +540,8 @@ func (o RestOptions) RestOptionApply(opts *RestOptions) {
 	if o.Usage != nil {
 		opts.Usage = o.Usage
 	}
+
+	opts.Validators = append(opts.Validators, o.Validators...)
 }
 
 func (o *RestOptions) applyName(name string) {
@@ -313,3 +555,35 @@ func (o *RestOptions) applyRestOptions(options []RestOptionApplyer) {
 		}
 	}
 }
+
+// Passthrough options.
+
+var _ PassthroughOptionApplyer = PassthroughOptions{}
+
+// PassthroughOptions have no validators: passthrough args are not parsed.
+type PassthroughOptions struct {
+	Name  string
+	Usage Usager
+}
+
+func (o PassthroughOptions) PassthroughOptionApply(opts *PassthroughOptions) {
+	if o.Name != "" {
+		opts.Name = o.Name
+	}
+
+	if o.Usage != nil {
+		opts.Usage = o.Usage
+	}
+}
+
+func (o *PassthroughOptions) applyName(name string) {
+	o.Name = name
+}
+
+func (o *PassthroughOptions) applyPassthroughOptions(options []PassthroughOptionApplyer) {
+	for _, opt := range options {
+		if opt != nil {
+			opt.PassthroughOptionApply(o)
+		}
+	}
+}
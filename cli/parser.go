@@ -3,8 +3,8 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
-	"time"
 )
 
 var (
@@ -21,12 +21,15 @@ var (
 	ErrArgAfterRest = errors.New("arg after rest")
 
 	ErrUnknown = errors.New("unknown")
+
+	ErrNotBool = errors.New("not a bool flag")
 )
 
 type ParseArgError struct {
-	Arg   string
-	Index int
-	Err   error
+	Arg         string
+	Index       int
+	Err         error
+	Suggestions []string // Similar command names for unknown args.
 }
 
 func (e *ParseArgError) Error() string {
@@ -67,8 +70,9 @@ func nthNumber(n int) string {
 }
 
 type ParseFlagError struct {
-	Name string
-	Err  error
+	Name        string
+	Err         error
+	Suggestions []string // Similar flag names for unknown flags.
 }
 
 func (e *ParseFlagError) Error() string {
@@ -90,7 +94,12 @@ func (e *ParseFlagError) Is(err error) bool {
 type FlagError struct {
 	Short string
 	Long  string
+	Env   string // Environment variable if the value was taken from it.
 	Err   error
+
+	// Config file and key if the value was taken from it.
+	ConfigFile string
+	ConfigKey  string
 }
 
 func (e *FlagError) Error() string {
@@ -179,7 +188,13 @@ type Register interface {
 	Err() error
 }
 
-var _ Register = (*DefaultRegister)(nil)
+var (
+	_ Register            = (*DefaultRegister)(nil)
+	_ ConstraintRegister  = (*DefaultRegister)(nil)
+	_ UnknownRegister     = (*DefaultRegister)(nil)
+	_ PassthroughRegister = (*DefaultRegister)(nil)
+	_ ObjectRegister      = (*DefaultRegister)(nil)
+)
 
 type DefaultRegister struct {
 	flags               flags
@@ -189,6 +204,16 @@ type DefaultRegister struct {
 	registerFlagErr     error    // RegisterFlag first error.
 	registerArgErr      error    // RegisterArg first error.
 	registerRestArgsErr error    // RegisterRestArgs first error.
+
+	constraints           []Constraint
+	registerConstraintErr error // RegisterConstraint first error.
+
+	unknown []string // Ignored flags and args.
+
+	passthrough            *PassthroughArgs // Arguments after "--".
+	registerPassthroughErr error            // RegisterPassthrough first error.
+
+	registerObjectErr error // RegisterObject first error.
 }
 
 func (r *DefaultRegister) RegisterFlag(flag Flag) (err error) {
@@ -225,6 +250,29 @@ func (r *DefaultRegister) RegisterFlag(flag Flag) (err error) {
 		}
 	}
 
+	// Validate aliases.
+	for _, alias := range flag.Aliases {
+		if (len(alias) == 1 && !validShortFlag(alias)) || (len(alias) != 1 && !validLongFlag(alias)) {
+			return &FlagError{
+				Short: flag.Short,
+				Long:  alias,
+				Err:   ErrInvalidName,
+			}
+		}
+	}
+
+	// Only bool flags with a long name can be negatable.
+	if flag.Negatable {
+		fv, ok := flag.Value.(boolFlag)
+		if !ok || !fv.IsBoolFlag() || flag.Long == "" {
+			return &FlagError{
+				Short: flag.Short,
+				Long:  flag.Long,
+				Err:   ErrNotBool,
+			}
+		}
+	}
+
 	if _, _, ok := r.flags.Find(flag.Long, flag.Short); ok {
 		return &FlagError{
 			Long:  flag.Long,
@@ -233,6 +281,49 @@ func (r *DefaultRegister) RegisterFlag(flag Flag) (err error) {
 		}
 	}
 
+	// All names must be unique, including aliases and negative forms.
+	seen := make(map[string]bool)
+	for _, name := range flag.ShortNames() {
+		if _, _, ok := r.flags.Find("", name); ok || seen["-"+name] {
+			return &FlagError{
+				Short: name,
+				Long:  flag.Long,
+				Err:   ErrDuplicate,
+			}
+		}
+
+		seen["-"+name] = true
+	}
+
+	longs := append(flag.LongNames(), flag.NegativeLongs()...)
+	for _, name := range append(longs, flag.FileLongs()...) {
+		if _, _, ok := r.flags.Find(name, ""); ok || seen["--"+name] {
+			return &FlagError{
+				Long:  name,
+				Short: flag.Short,
+				Err:   ErrDuplicate,
+			}
+		}
+
+		seen["--"+name] = true
+	}
+
+	if err := checkChoices(flag.Value); err != nil {
+		return &FlagError{
+			Short: flag.Short,
+			Long:  flag.Long,
+			Err:   err,
+		}
+	}
+
+	if err := checkValidators(flag.Value, flag.Validators); err != nil {
+		return &FlagError{
+			Short: flag.Short,
+			Long:  flag.Long,
+			Err:   err,
+		}
+	}
+
 	r.flags.Add(flag)
 
 	return nil
@@ -259,6 +350,11 @@ func validLongFlag(name string) bool {
 		return false
 	}
 
+	// Dotted paths (e.g. "db.pool.size") cannot have empty parts.
+	if len(name) > 1 && name[len(name)-1] == '.' {
+		return false
+	}
+
 	// We need to iterate by bytes not by runes.
 	var foundValid bool
 	for i := 1; i < len(name); i++ {
@@ -272,6 +368,10 @@ func validLongFlag(name string) bool {
 			return false
 		}
 
+		if c == '.' && name[i-1] == '.' {
+			return false
+		}
+
 		foundValid = true
 	}
 
@@ -321,6 +421,20 @@ func (r *DefaultRegister) RegisterArg(arg Arg) (err error) {
 		}
 	}
 
+	if err := checkChoices(arg.Value); err != nil {
+		return &ArgError{
+			Name: arg.Name,
+			Err:  err,
+		}
+	}
+
+	if err := checkValidators(arg.Value, arg.Validators); err != nil {
+		return &ArgError{
+			Name: arg.Name,
+			Err:  err,
+		}
+	}
+
 	r.args.Add(arg)
 
 	return nil
@@ -379,6 +493,20 @@ func (r *DefaultRegister) RegisterRestArgs(rest RestArgs) (err error) {
 		}
 	}
 
+	if err := checkChoices(rest.Values); err != nil {
+		return &RestArgsError{
+			Name: rest.Name,
+			Err:  err,
+		}
+	}
+
+	if err := checkValidators(rest.Values, rest.Validators); err != nil {
+		return &RestArgsError{
+			Name: rest.Name,
+			Err:  err,
+		}
+	}
+
 	r.rest = rest
 
 	return nil
@@ -432,6 +560,18 @@ func (r *DefaultRegister) Err() error {
 		return r.registerRestArgsErr
 	}
 
+	if r.registerConstraintErr != nil {
+		return r.registerConstraintErr
+	}
+
+	if r.registerPassthroughErr != nil {
+		return r.registerPassthroughErr
+	}
+
+	if r.registerObjectErr != nil {
+		return r.registerObjectErr
+	}
+
 	return nil
 }
 
@@ -440,6 +580,38 @@ type Commander interface {
 	SetCommand(name string) (Register, error)
 }
 
+// FirstArgStopper is an optional interface of a Commander. The parser treats
+// all arguments after the first positional argument as args if the current
+// command requires it.
+type FirstArgStopper interface {
+	StopAtFirstArg() bool
+}
+
+// EnvLookuper is an optional interface of a Commander. The parser uses it to
+// read values of flags bound to environment variables instead of the
+// os.LookupEnv.
+type EnvLookuper interface {
+	LookupEnv(key string) (string, bool)
+}
+
+// Warner is an optional interface of a Commander. The parser uses it to print
+// warnings (e.g. about deprecated flags) instead of the os.Stderr.
+type Warner interface {
+	Warn(msg string)
+}
+
+// CommandSuggester is an optional interface of a Commander. The parser uses it
+// to find similar command names for unknown arguments.
+type CommandSuggester interface {
+	SuggestCommands(name string) []string
+}
+
+// CommandMatcher is an optional interface of a Commander. The parser uses it to
+// find commands by a prefix of their names or aliases.
+type CommandMatcher interface {
+	MatchCommands(prefix string) []string
+}
+
 type flags struct {
 	data  []Flag
 	set   []bool         // Markers if flags were set.
@@ -451,9 +623,28 @@ func (f *flags) LongFlag(name string) (idx int, flag *Flag, ok bool) {
 	idx, ok = f.long[name]
 	if ok {
 		flag = &f.data[idx]
+		return
 	}
 
-	return
+	// Find the closest object flag for a dotted path: "db.pool.size" ->
+	// "db.pool" -> "db".
+	for i := len(name) - 1; i > 0; i-- {
+		if name[i] != '.' {
+			continue
+		}
+
+		idx, ok = f.long[name[:i]]
+		if !ok {
+			continue
+		}
+
+		if fv, isObject := f.data[idx].Value.(objectFlag); isObject && fv.IsObjectFlag() {
+			flag = &f.data[idx]
+			return
+		}
+	}
+
+	return -1, nil, false
 }
 
 func (f *flags) ShortFlag(name string) (idx int, flag *Flag, ok bool) {
@@ -497,21 +688,41 @@ func (f *flags) Add(flag Flag) {
 	f.set = append(f.set, false)
 	idx := len(f.data) - 1
 
-	if flag.Long != "" {
+	// Aliases, "--no-<long>" and "--<long>-file" forms point to the same flag.
+	names := append(flag.LongNames(), flag.NegativeLongs()...)
+	for _, name := range append(names, flag.FileLongs()...) {
 		if f.long == nil {
 			f.long = make(map[string]int)
 		}
 
-		f.long[flag.Long] = idx
+		f.long[name] = idx
 	}
 
-	if flag.Short != "" {
+	for _, name := range flag.ShortNames() {
 		if f.short == nil {
 			f.short = make(map[string]int)
 		}
 
-		f.short[flag.Short] = idx
+		f.short[name] = idx
+	}
+}
+
+// truncate removes flags added after the first n flags.
+func (f *flags) truncate(n int) {
+	for name, idx := range f.long {
+		if idx >= n {
+			delete(f.long, name)
+		}
+	}
+
+	for name, idx := range f.short {
+		if idx >= n {
+			delete(f.short, name)
+		}
 	}
+
+	f.data = f.data[:n]
+	f.set = f.set[:n]
 }
 
 func (f *flags) Reset() {
@@ -576,11 +787,19 @@ type DefaultParser struct {
 	Universal          bool
 	IgnoreUnknownFlags bool
 	IgnoreUnknownArgs  bool
+	UnknownFlagValues  bool // Ignore the next argument as a value of an ignored flag if no args can take it.
 	DisablePosixStyle  bool
 	DisableInlineValue bool
+	CommandPrefixes    bool // Accept unique prefixes of command names.
+	ResponseFiles      bool // Replace "@file" arguments with arguments from the file.
+
+	// StopAtFirstArg treats all arguments after the first positional argument
+	// as args.
+	StopAtFirstArg bool
 
-	// TODO(SuperPaitnamn): allow access to the unknown flags.
-	// unknown []string // Unknown flags (without named flags).
+	// StopAtFirstArg is enabled if the POSIXLY_CORRECT environment variable is
+	// set. IgnorePosixlyCorrect disables it.
+	IgnorePosixlyCorrect bool
 }
 
 func (p *DefaultParser) Parse(commander Commander, r Register, arguments []string) error {
@@ -589,12 +808,55 @@ func (p *DefaultParser) Parse(commander Commander, r Register, arguments []strin
 		return err
 	}
 
+	// Expand response files before flags and commands resolution.
+	if p.ResponseFiles {
+		expanded, err := p.expandResponseFiles(arguments)
+		if err != nil {
+			return err
+		}
+
+		arguments = expanded
+	}
+
 	var (
 		argMode          bool
 		argIdx           int
 		flagsTerminated  bool
+		foundTerminator  bool // An explicit "--" was found.
 		foundCommandFlag bool
 	)
+
+	// All registers of the command chain.
+	registers := []Register{r}
+
+	warn := func(msg string) { fmt.Fprintln(os.Stderr, msg) }
+	if w, ok := commander.(Warner); ok {
+		warn = w.Warn
+	}
+
+	// The environment is looked up once per invocation.
+	var posixlyCorrect bool
+	if !p.IgnorePosixlyCorrect {
+		lookupEnv := os.LookupEnv
+		if el, ok := commander.(EnvLookuper); ok {
+			lookupEnv = el.LookupEnv
+		}
+
+		_, posixlyCorrect = lookupEnv("POSIXLY_CORRECT")
+	}
+
+	// Warn only once per invocation about every deprecated item.
+	warned := make(map[string]bool)
+	warnDeprecated := func(kind, name, replacement string, d *Deprecation) {
+		key := kind + " " + name
+		if warned[key] {
+			return
+		}
+		warned[key] = true
+
+		warn(DeprecationWarning(kind, name, replacement, d.Message))
+	}
+
 	for {
 		if len(arguments) == 0 {
 			break
@@ -603,25 +865,52 @@ func (p *DefaultParser) Parse(commander Commander, r Register, arguments []strin
 		arg := arguments[0]
 		arguments = arguments[1:]
 
+		// Arguments after "--" are passed through as is. A second "--" is an
+		// arg.
+		if arg == "--" && !foundTerminator {
+			if pt := passthroughOf(r); pt != nil {
+				*pt.Values = append(*pt.Values, arguments...)
+				break
+			}
+		}
+
 		// Commands or Args.
 		if len(arg) == 0 || flagsTerminated || arg[0] != '-' || arg == "-" || isNumber(arg) || isDuration(arg) {
 			// Check if the arg is a command.
-			if !argMode && commander != nil && commander.IsCommand(arg) {
-				register, err := commander.SetCommand(arg)
+			if !argMode && commander != nil {
+				name, err := p.matchCommand(commander, r, arg)
 				if err != nil {
 					return err
 				}
 
-				r = register
-				continue
+				if name != "" {
+					register, err := commander.SetCommand(name)
+					if err != nil {
+						return err
+					}
+
+					r = register
+					registers = append(registers, r)
+					continue
+				}
 			}
 
 			// Parse rest as args.
 			argMode = true
 
+			// Flags after the first positional argument are args.
+			if !flagsTerminated && (posixlyCorrect || p.stopAtFirstArg(commander)) {
+				flagsTerminated = true
+			}
+
 			a, ok := r.Arg(argIdx)
 			if ok {
-				if err := a.Value.Set(arg); err != nil {
+				err := a.Value.Set(arg)
+				if err == nil {
+					err = validateValue(a.Value, a.Validators)
+				}
+
+				if err != nil {
 					return &ArgError{
 						Name:  a.Name,
 						Index: argIdx,
@@ -630,18 +919,35 @@ func (p *DefaultParser) Parse(commander Commander, r Register, arguments []strin
 				}
 
 				a.MarkSet()
+
+				if a.Deprecation != nil {
+					replacement, err := p.forwardArg(r, a, arg)
+					if err != nil {
+						return err
+					}
+
+					warnDeprecated("argument", a.Name, replacement, a.Deprecation)
+				}
 			} else {
 				rest := r.Rest()
 				if rest == nil {
 					if p.IgnoreUnknownArgs {
+						addUnknown(r, arg)
 						argIdx++
 						continue
 					}
 
+					var suggestions []string
+					if cs, ok := commander.(CommandSuggester); ok && argIdx == 0 {
+						// The first unknown arg might be a misspelled command.
+						suggestions = cs.SuggestCommands(arg)
+					}
+
 					return &ParseArgError{
-						Arg:   arg,
-						Index: argIdx,
-						Err:   ErrUnknown,
+						Arg:         arg,
+						Index:       argIdx,
+						Err:         ErrUnknown,
+						Suggestions: suggestions,
 					}
 				}
 
@@ -667,6 +973,7 @@ func (p *DefaultParser) Parse(commander Commander, r Register, arguments []strin
 			// "--" terminates the flags.
 			if len(arg) == 2 {
 				flagsTerminated = true
+				foundTerminator = true
 				continue
 			}
 		}
@@ -697,6 +1004,7 @@ func (p *DefaultParser) Parse(commander Commander, r Register, arguments []strin
 		}
 
 		// Find a known flag.
+		tokenName := name
 		restName := name
 		prevHasValue := hasValue
 		prevValue := value
@@ -705,6 +1013,8 @@ func (p *DefaultParser) Parse(commander Commander, r Register, arguments []strin
 				flag          *Flag
 				knownflag     bool
 				lastShortFlag bool
+				negative      bool
+				fileForm      bool // The "--<long>-file" form.
 			)
 			if shortFlag {
 				originalName := name
@@ -724,7 +1034,7 @@ func (p *DefaultParser) Parse(commander Commander, r Register, arguments []strin
 
 				if knownflag {
 					// Parse Short-flag+parameter combining (-a parm -> -aparm).
-					if _, ok := flag.Value.(boolFlag); !ok && !p.DisableInlineValue && len(restName) > 0 {
+					if !isBoolFlag(flag.Value) && !isCountFlag(flag.Value) && !p.DisableInlineValue && len(restName) > 0 {
 						hasValue = true
 						value = restName
 						restName = ""
@@ -748,10 +1058,30 @@ func (p *DefaultParser) Parse(commander Commander, r Register, arguments []strin
 				if !knownflag && p.Universal {
 					flag, knownflag = r.ShortFlag(name)
 				}
+
+				negative = knownflag && flag.isNegativeLong(name)
+				fileForm = knownflag && flag.isFileLong(name)
 			}
 
 			if !knownflag {
 				if p.IgnoreUnknownFlags {
+					token := arg[:numMinuses] + name
+					if shortFlag && p.DisablePosixStyle {
+						// The whole group is one unknown flag.
+						token = arg
+						restName = ""
+					} else if hasValue {
+						token += "=" + value
+					}
+
+					unknown := []string{token}
+					if p.UnknownFlagValues && !hasValue && !prevHasValue && len(restName) == 0 && len(arguments) > 0 &&
+						p.unknownFlagValue(commander, r, argMode, argIdx, arguments[0]) {
+						unknown = append(unknown, arguments[0])
+						arguments = arguments[1:]
+					}
+
+					addUnknown(r, unknown...)
 					continue
 				}
 
@@ -763,12 +1093,19 @@ func (p *DefaultParser) Parse(commander Commander, r Register, arguments []strin
 				}
 
 				return &ParseFlagError{
-					Name: fullName,
-					Err:  ErrUnknown,
+					Name:        fullName,
+					Err:         ErrUnknown,
+					Suggestions: p.suggestFlags(r, name, tokenName, shortFlag),
 				}
 			}
 
-			if (!shortFlag || lastShortFlag) && !hasValue && len(arguments) > 0 {
+			// Negative form, counters and flags with optional values do not
+			// take the next argument as a value. The file form always takes
+			// a path.
+			if flag.ImplicitValue != nil && !hasValue && !fileForm {
+				value = *flag.ImplicitValue
+				hasValue = true
+			} else if (!shortFlag || lastShortFlag) && !hasValue && !negative && (fileForm || !isCountFlag(flag.Value)) && len(arguments) > 0 {
 				next := arguments[0]
 
 				var setValue bool
@@ -779,7 +1116,7 @@ func (p *DefaultParser) Parse(commander Commander, r Register, arguments []strin
 					}
 				} else if len(next) > 0 && (next[0] != '-' || next == "-" || isNumber(next) || isDuration(next)) {
 					// Special case for bool flags. Allow only bool-like values.
-					if fv, ok := flag.Value.(boolFlag); ok && fv.IsBoolFlag() {
+					if fv, ok := flag.Value.(boolFlag); ok && fv.IsBoolFlag() && !fileForm {
 						setValue = isBoolValue(next)
 					} else {
 						setValue = true
@@ -793,6 +1130,20 @@ func (p *DefaultParser) Parse(commander Commander, r Register, arguments []strin
 				}
 			}
 
+			// Read the value from a file: "--<long>-file path" or "--<long>=@path".
+			if flag.FromFile && hasValue {
+				v, err := flagFileValue(value, fileForm)
+				if err != nil {
+					return &FlagError{
+						Short: flag.Short,
+						Long:  flag.Long,
+						Err:   err,
+					}
+				}
+
+				value = v
+			}
+
 			// Set Value.
 			// Special case for bool flags which doesn't need a value.
 			if fv, ok := flag.Value.(boolFlag); ok && fv.IsBoolFlag() {
@@ -803,11 +1154,42 @@ func (p *DefaultParser) Parse(commander Commander, r Register, arguments []strin
 				}
 			}
 
-			if err := flag.Value.Set(value); err != nil {
+			// Invert a value of the "--no-<long>" form.
+			if negative {
+				b, err := parseBool(value)
+				if err != nil {
+					return &FlagError{
+						Short: flag.Short,
+						Long:  flag.Long,
+						Err: &ParseValueError{
+							Type: "bool",
+							Err:  err,
+						},
+					}
+				}
+
+				value = strconv.FormatBool(!b)
+			}
+
+			var err error
+			if fv, ok := flag.Value.(countFlag); ok && fv.IsCountFlag() && !hasValue {
+				fv.Increment()
+			} else if fv, ok := flag.Value.(objectFlag); ok && fv.IsObjectFlag() && !shortFlag && !flag.isLongName(name) {
+				// Dotted path inside the object: "--db.host" -> "host".
+				err = fv.SetKey(objectKey(flag, name), value)
+			} else {
+				err = flag.Value.Set(value)
+			}
+
+			if err == nil {
+				err = validateValue(flag.Value, flag.Validators)
+			}
+
+			if err != nil {
 				return &FlagError{
 					Short: flag.Short,
 					Long:  flag.Long,
-					Err:   err,
+					Err:   flag.maskError(err),
 				}
 			}
 
@@ -817,14 +1199,70 @@ func (p *DefaultParser) Parse(commander Commander, r Register, arguments []strin
 
 			// Mark the flag as set.
 			flag.MarkSet()
+
+			if flag.Deprecation != nil {
+				replacement, err := p.forwardFlag(r, flag, value, true)
+				if err != nil {
+					return err
+				}
+
+				warnDeprecated("flag", p.formatFlag(flag), replacement, flag.Deprecation)
+			}
 		}
 	}
 
+	// Deprecated flags from environment variables and config files don't
+	// override replacements which are already set.
+	deprecatedFlag := func(flag *Flag, values []string) error {
+		if flag.Deprecation == nil {
+			return nil
+		}
+
+		// All values are forwarded if the replacement was not set before.
+		override := false
+		if rf, ok := replacementFlag(r, flag); ok {
+			override = !rf.Set()
+		}
+
+		var replacement string
+		for _, value := range values {
+			var err error
+			replacement, err = p.forwardFlag(r, flag, value, override)
+			if err != nil {
+				return err
+			}
+		}
+
+		warnDeprecated("flag", p.formatFlag(flag), replacement, flag.Deprecation)
+		return nil
+	}
+
 	// Don't chec required flags and args if we in "command flag" mode.
 	if foundCommandFlag {
 		return nil
 	}
 
+	// Set values from environment variables.
+	lookupEnv := os.LookupEnv
+	if el, ok := commander.(EnvLookuper); ok {
+		lookupEnv = el.LookupEnv
+	}
+
+	for _, reg := range registers {
+		if err := setFlagsFromEnv(reg, r, lookupEnv, deprecatedFlag); err != nil {
+			return err
+		}
+	}
+
+	// Set values from config files.
+	if cl, ok := commander.(ConfigLookuper); ok {
+		for depth, reg := range registers {
+			if err := setFlagsFromConfig(reg, r, depth, cl.LookupConfig, deprecatedFlag); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Check required flags.
 	flags := r.Flags()
 	for i := range flags {
@@ -839,6 +1277,11 @@ func (p *DefaultParser) Parse(commander Commander, r Register, arguments []strin
 		}
 	}
 
+	// Check constraints of flags.
+	if err := p.checkConstraints(registers); err != nil {
+		return err
+	}
+
 	// Check required args.
 	args := r.Args()
 	for i := range args {
@@ -855,6 +1298,291 @@ func (p *DefaultParser) Parse(commander Commander, r Register, arguments []strin
 	return nil
 }
 
+func (p *DefaultParser) stopAtFirstArg(commander Commander) bool {
+	if p.StopAtFirstArg {
+		return true
+	}
+
+	fs, ok := commander.(FirstArgStopper)
+	return ok && fs.StopAtFirstArg()
+}
+
+func setFlagsFromEnv(r, last Register, lookupEnv func(key string) (string, bool), deprecated func(flag *Flag, values []string) error) error {
+	flags := r.Flags()
+	for i := range flags {
+		flag := &flags[i]
+
+		if flag.Set() || flag.Env == "" {
+			continue
+		}
+
+		// The value is set via the inherited copy of the flag.
+		if r != last && flag.Global && inheritedFlag(last, flag) {
+			continue
+		}
+
+		// Empty variables are treated as unset.
+		value, ok := lookupEnv(flag.Env)
+		if !ok || value == "" {
+			continue
+		}
+
+		// Read the value from a file: "@path".
+		if flag.FromFile {
+			v, err := flagFileValue(value, false)
+			if err != nil {
+				return &FlagError{
+					Short: flag.Short,
+					Long:  flag.Long,
+					Env:   flag.Env,
+					Err:   err,
+				}
+			}
+
+			value = v
+		}
+
+		err := flag.Value.Set(value)
+		if err == nil {
+			err = validateValue(flag.Value, flag.Validators)
+		}
+
+		if err != nil {
+			return &FlagError{
+				Short: flag.Short,
+				Long:  flag.Long,
+				Env:   flag.Env,
+				Err:   flag.maskError(err),
+			}
+		}
+
+		flag.MarkSet()
+
+		if err := deprecated(flag, []string{value}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// matchCommand returns a name of the command or an empty string if the arg is
+// not a command. Prefixes are matched only if the register has no args to
+// fill.
+func (p *DefaultParser) matchCommand(commander Commander, r Register, arg string) (string, error) {
+	if commander.IsCommand(arg) {
+		return arg, nil
+	}
+
+	cm, ok := commander.(CommandMatcher)
+	if !p.CommandPrefixes || !ok || arg == "" {
+		return "", nil
+	}
+
+	if len(r.Args()) > 0 || r.Rest() != nil {
+		return "", nil
+	}
+
+	switch names := cm.MatchCommands(arg); len(names) {
+	case 0:
+		return "", nil
+
+	case 1:
+		return names[0], nil
+
+	default:
+		return "", &AmbiguousCommandError{
+			Name:       arg,
+			Candidates: names,
+		}
+	}
+}
+
+// forwardFlag sets the value of a deprecated flag to its replacement and
+// returns the formatted name of the replacement. The value of a replacement
+// which is already set is kept unless override is true.
+func (p *DefaultParser) forwardFlag(r Register, flag *Flag, value string, override bool) (string, error) {
+	name := flag.Deprecation.Replacement
+	if name == "" {
+		return "", nil
+	}
+
+	replacement, ok := replacementFlag(r, flag)
+	if !ok {
+		return "", &FlagError{
+			Long: name,
+			Err:  ErrUnknown,
+		}
+	}
+
+	if replacement.Set() && !override {
+		return p.formatFlag(replacement), nil
+	}
+
+	var err error
+	if fv, ok := replacement.Value.(countFlag); ok && fv.IsCountFlag() && value == "" {
+		fv.Increment()
+	} else {
+		err = replacement.Value.Set(value)
+	}
+
+	if err == nil {
+		err = validateValue(replacement.Value, replacement.Validators)
+	}
+
+	if err != nil {
+		return "", &FlagError{
+			Short: replacement.Short,
+			Long:  replacement.Long,
+			Err:   replacement.maskError(err),
+		}
+	}
+
+	replacement.MarkSet()
+
+	return p.formatFlag(replacement), nil
+}
+
+// replacementFlag returns the replacement of the deprecated flag.
+func replacementFlag(r Register, flag *Flag) (*Flag, bool) {
+	name := flag.Deprecation.Replacement
+	if name == "" {
+		return nil, false
+	}
+
+	if replacement, ok := r.LongFlag(name); ok {
+		return replacement, true
+	}
+
+	return r.ShortFlag(name)
+}
+
+// forwardArg sets the value of a deprecated arg to its replacement (an arg or
+// a flag) and returns the formatted name of the replacement.
+func (p *DefaultParser) forwardArg(r Register, arg *Arg, value string) (string, error) {
+	name := arg.Deprecation.Replacement
+	if name == "" {
+		return "", nil
+	}
+
+	if i, replacement, ok := findArg(r.Args(), name); ok {
+		err := replacement.Value.Set(value)
+		if err == nil {
+			err = validateValue(replacement.Value, replacement.Validators)
+		}
+
+		if err != nil {
+			return "", &ArgError{
+				Name:  replacement.Name,
+				Index: i,
+				Err:   err,
+			}
+		}
+
+		replacement.MarkSet()
+
+		return "<" + replacement.Name + ">", nil
+	}
+
+	if flag, ok := r.LongFlag(name); ok {
+		err := flag.Value.Set(value)
+		if err == nil {
+			err = validateValue(flag.Value, flag.Validators)
+		}
+
+		if err != nil {
+			return "", &FlagError{
+				Short: flag.Short,
+				Long:  flag.Long,
+				Err:   flag.maskError(err),
+			}
+		}
+
+		flag.MarkSet()
+
+		return p.FormatLongFlag(flag.Long), nil
+	}
+
+	return "", &ArgError{
+		Name: name,
+		Err:  ErrUnknown,
+	}
+}
+
+// DeprecationWarning returns a warning about a deprecated flag, arg or
+// command.
+func DeprecationWarning(kind, name, replacement, message string) string {
+	msg := "Warning: " + kind + " " + name + " is deprecated"
+	if replacement != "" {
+		msg += ", use " + replacement + " instead"
+	}
+
+	if message != "" {
+		msg += ": " + message
+	}
+
+	return msg
+}
+
+// objectKey returns a key inside the object flag: "db.pool.size" -> "pool.size".
+func objectKey(flag *Flag, name string) string {
+	for _, long := range flag.LongNames() {
+		if len(name) > len(long) && name[:len(long)] == long && name[len(long)] == '.' {
+			return name[len(long)+1:]
+		}
+	}
+
+	return name
+}
+
+// inheritedFlag reports whether the register has an inherited copy of the
+// global flag.
+func inheritedFlag(r Register, flag *Flag) bool {
+	var (
+		f  *Flag
+		ok bool
+	)
+	if flag.Long != "" {
+		f, ok = r.LongFlag(flag.Long)
+	} else {
+		f, ok = r.ShortFlag(flag.Short)
+	}
+
+	return ok && f.inherited
+}
+
+func (p *DefaultParser) suggestFlags(r Register, name, tokenName string, shortFlag bool) []string {
+	s := newSuggester(name)
+
+	flags := visibleFlags(r.Flags())
+	for i := range flags {
+		flag := &flags[i]
+
+		for _, long := range append(flag.LongNames(), flag.NegativeLongs()...) {
+			s.Add(long, p.FormatLongFlag(long))
+		}
+
+		for _, short := range flag.ShortNames() {
+			s.Add(short, p.FormatShortFlag(short))
+		}
+	}
+
+	// A long flag with a single dash (e.g. "-verbose") is parsed as combined
+	// short flags, so we compare the whole token with long names as well.
+	if shortFlag && len(tokenName) > 1 {
+		ls := newSuggester(tokenName)
+		for i := range flags {
+			for _, long := range flags[i].LongNames() {
+				ls.Add(long, p.FormatLongFlag(long))
+			}
+		}
+
+		return append(ls.Suggestions(), s.Suggestions()...)
+	}
+
+	return s.Suggestions()
+}
+
 func (p *DefaultParser) FormatLongFlag(name string) string {
 	if name == "" {
 		return ""
@@ -875,6 +1603,32 @@ func (p *DefaultParser) FormatShortFlag(name string) string {
 	return "-" + name
 }
 
+func lookupFlag(r Register, name string) (*Flag, bool) {
+	if len(name) == 1 {
+		return r.ShortFlag(name)
+	}
+
+	return r.LongFlag(name)
+}
+
+func (p *DefaultParser) formatFlag(flag *Flag) string {
+	if flag.Long != "" {
+		return p.FormatLongFlag(flag.Long)
+	}
+
+	return p.FormatShortFlag(flag.Short)
+}
+
+func isBoolFlag(v Value) bool {
+	fv, ok := v.(boolFlag)
+	return ok && fv.IsBoolFlag()
+}
+
+func isCountFlag(v Value) bool {
+	fv, ok := v.(countFlag)
+	return ok && fv.IsCountFlag()
+}
+
 func isNumber(s string) bool {
 	// TODO(SuperPaintman): optimize it.
 
@@ -896,7 +1650,7 @@ func isNumber(s string) bool {
 func isDuration(s string) bool {
 	// TODO(SuperPaintman): optimize it.
 
-	if _, err := time.ParseDuration(s); err == nil {
+	if _, err := parseDuration(s); err == nil {
 		return true
 	}
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"os"
 	"reflect"
 	"strconv"
 	"testing"
@@ -11,6 +12,13 @@ import (
 	"unsafe"
 )
 
+func TestMain(m *testing.M) {
+	// POSIXLY_CORRECT changes the default parsing mode.
+	_ = os.Unsetenv("POSIXLY_CORRECT")
+
+	os.Exit(m.Run())
+}
+
 const (
 	maxUint = ^uint(0)
 	minUint = 0
@@ -982,6 +990,26 @@ func TestRegisterInvalidNameFlag(t *testing.T) {
 			long: "help,test",
 			want: &FlagError{Long: "help,test", Err: ErrInvalidName},
 		},
+		{
+			name: "dotted long name",
+			long: "db.pool.size",
+			want: nil,
+		},
+		{
+			name: "ignore start dot in long name",
+			long: ".db",
+			want: nil,
+		},
+		{
+			name: "end dot in long name",
+			long: "db.",
+			want: &FlagError{Long: "db.", Err: ErrInvalidName},
+		},
+		{
+			name: "empty part in long name",
+			long: "db..host",
+			want: &FlagError{Long: "db..host", Err: ErrInvalidName},
+		},
 	}
 
 	for _, tc := range tt {
@@ -1683,6 +1711,59 @@ func TestParser_Parse_unknown_flags_with_value(t *testing.T) {
 	}
 }
 
+func TestParser_Parse_unknown_flags_suggestions(t *testing.T) {
+	tt := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			name: "transposition",
+			args: []string{"--jbos", "1"},
+			want: []string{"--jobs"},
+		},
+		{
+			name: "prefix",
+			args: []string{"--verb"},
+			want: []string{"--verbose"},
+		},
+		{
+			name: "long flag with a single dash",
+			args: []string{"-verbose"},
+			want: []string{"--verbose"},
+		},
+		{
+			name: "no suggestions",
+			args: []string{"--unknown"},
+			want: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			_ = Int(&register, "jobs", WithShort("j"))
+			_ = Bool(&register, "verbose")
+			_ = Bool(&register, "dry-run")
+
+			err := parser.Parse(nil, &register, tc.args)
+
+			var got *ParseFlagError
+			if !errors.As(err, &got) {
+				t.Fatalf("Parse(): got error = %q, want ParseFlagError", err)
+			}
+
+			if !reflect.DeepEqual(got.Suggestions, tc.want) {
+				t.Errorf("Parse(): suggestions: got = %#v, want = %#v", got.Suggestions, tc.want)
+			}
+		})
+	}
+}
+
 func TestParser_Parse_ignore_unknown_flags(t *testing.T) {
 	var register DefaultRegister
 	parser := DefaultParser{
@@ -2242,6 +2323,452 @@ func TestParser_Parse_flags_terminator(t *testing.T) {
 	}
 }
 
+func TestParser_Parse_negatable_flags(t *testing.T) {
+	tt := []struct {
+		name      string
+		args      []string
+		wantColor bool
+		wantArg   string
+	}{
+		{
+			name:      "default",
+			args:      []string{"arg"},
+			wantColor: true,
+			wantArg:   "arg",
+		},
+		{
+			name:      "positive",
+			args:      []string{"--color", "arg"},
+			wantColor: true,
+			wantArg:   "arg",
+		},
+		{
+			name:      "negative",
+			args:      []string{"--no-color", "arg"},
+			wantColor: false,
+			wantArg:   "arg",
+		},
+		{
+			name:      "negative does not take the next bool-like arg",
+			args:      []string{"--no-color", "true"},
+			wantColor: false,
+			wantArg:   "true",
+		},
+		{
+			name:      "negative with inline value",
+			args:      []string{"--no-color=false", "arg"},
+			wantColor: true,
+			wantArg:   "arg",
+		},
+		{
+			name:      "last wins",
+			args:      []string{"--no-color", "--color", "arg"},
+			wantColor: true,
+			wantArg:   "arg",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			color := true
+			_ = BoolVar(&register, &color, "color", Negatable)
+			arg := StringArg(&register, "arg")
+
+			if err := parser.Parse(nil, &register, tc.args); err != nil {
+				t.Fatalf("Parse(): failed to parse args: %s", err)
+			}
+
+			assertParseBoolFlags(t, "color", color, tc.wantColor)
+
+			if *arg != tc.wantArg {
+				t.Errorf("Parse(): arg: got = %q, want = %q", *arg, tc.wantArg)
+			}
+		})
+	}
+}
+
+func TestRegisterNegatableFlag(t *testing.T) {
+	tt := []struct {
+		name     string
+		register func(r Register) error
+		want     error
+	}{
+		{
+			name: "duplicate negative form",
+			register: func(r Register) error {
+				_ = Bool(r, "color", Negatable)
+				return BoolVar(r, new(bool), "no-color")
+			},
+			want: &FlagError{Long: "no-color", Err: ErrDuplicate},
+		},
+		{
+			name: "negative form of a registered flag",
+			register: func(r Register) error {
+				_ = Bool(r, "no-color")
+				return BoolVar(r, new(bool), "color", Negatable)
+			},
+			want: &FlagError{Long: "no-color", Err: ErrDuplicate},
+		},
+		{
+			name: "non-bool",
+			register: func(r Register) error {
+				return StringVar(r, new(string), "color", Negatable)
+			},
+			want: &FlagError{Long: "color", Err: ErrNotBool},
+		},
+		{
+			name: "without long name",
+			register: func(r Register) error {
+				return BoolVar(r, new(bool), "c", Negatable)
+			},
+			want: &FlagError{Short: "c", Err: ErrNotBool},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var register DefaultRegister
+
+			got := tc.register(&register)
+			if !errors.Is(got, tc.want) {
+				t.Fatalf("RegisterFlag(): got error = %q, want error = %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParser_Parse_flag_aliases(t *testing.T) {
+	tt := []struct {
+		name       string
+		args       []string
+		wantDryRun bool
+		wantColor  bool
+		wantOutput string
+	}{
+		{
+			name:       "names",
+			args:       []string{"--dry-run", "--color", "--output", "a.txt"},
+			wantDryRun: true,
+			wantColor:  true,
+			wantOutput: "a.txt",
+		},
+		{
+			name:       "long aliases",
+			args:       []string{"--dry", "--colour", "--out=a.txt"},
+			wantDryRun: true,
+			wantColor:  true,
+			wantOutput: "a.txt",
+		},
+		{
+			name:       "short aliases",
+			args:       []string{"-nO", "a.txt"},
+			wantDryRun: true,
+			wantOutput: "a.txt",
+		},
+		{
+			name:       "negative alias",
+			args:       []string{"--colour", "--no-colour"},
+			wantColor:  false,
+			wantOutput: "-",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			dryRun := Bool(&register, "dry-run", WithAlias("dry"), WithAlias("n"))
+			color := Bool(&register, "color", WithAlias("colour"), Negatable)
+			output := String(&register, "output",
+				WithShort("o"),
+				WithAlias("out"),
+				WithAlias("O"),
+				Required,
+			)
+			*output = "-"
+
+			args := append([]string{}, tc.args...)
+			if tc.wantOutput == "-" {
+				args = append(args, "-o", "-")
+			}
+
+			if err := parser.Parse(nil, &register, args); err != nil {
+				t.Fatalf("Parse(): failed to parse args: %s", err)
+			}
+
+			assertParseBoolFlags(t, "dry-run", *dryRun, tc.wantDryRun)
+			assertParseBoolFlags(t, "color", *color, tc.wantColor)
+
+			if *output != tc.wantOutput {
+				t.Errorf("Parse(): output: got = %q, want = %q", *output, tc.wantOutput)
+			}
+		})
+	}
+}
+
+func TestRegisterFlagAliases(t *testing.T) {
+	tt := []struct {
+		name     string
+		register func(r Register) error
+		want     error
+	}{
+		{
+			name: "alias of a registered flag",
+			register: func(r Register) error {
+				_ = Bool(r, "dry")
+				return BoolVar(r, new(bool), "dry-run", WithAlias("dry"))
+			},
+			want: &FlagError{Long: "dry", Err: ErrDuplicate},
+		},
+		{
+			name: "flag with a registered alias",
+			register: func(r Register) error {
+				_ = Bool(r, "dry-run", WithAlias("dry"))
+				return BoolVar(r, new(bool), "dry")
+			},
+			want: &FlagError{Long: "dry", Err: ErrDuplicate},
+		},
+		{
+			name: "short alias",
+			register: func(r Register) error {
+				_ = Bool(r, "dry-run", WithAlias("n"))
+				return BoolVar(r, new(bool), "n")
+			},
+			want: &FlagError{Short: "n", Err: ErrDuplicate},
+		},
+		{
+			name: "negative form of an alias",
+			register: func(r Register) error {
+				_ = Bool(r, "color", WithAlias("colour"), Negatable)
+				return BoolVar(r, new(bool), "no-colour")
+			},
+			want: &FlagError{Long: "no-colour", Err: ErrDuplicate},
+		},
+		{
+			name: "same alias twice",
+			register: func(r Register) error {
+				return BoolVar(r, new(bool), "dry-run", WithAlias("dry"), WithAlias("dry"))
+			},
+			want: &FlagError{Long: "dry", Err: ErrDuplicate},
+		},
+		{
+			name: "invalid alias",
+			register: func(r Register) error {
+				return BoolVar(r, new(bool), "dry-run", WithAlias("-dry"))
+			},
+			want: &FlagError{Long: "-dry", Err: ErrInvalidName},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var register DefaultRegister
+
+			got := tc.register(&register)
+			if !errors.Is(got, tc.want) {
+				t.Fatalf("RegisterFlag(): got error = %q, want error = %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParser_Parse_count_flags(t *testing.T) {
+	tt := []struct {
+		name        string
+		args        []string
+		wantVerbose int
+		wantQuiet   bool
+		wantArg     string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:        "single",
+			args:        []string{"-v"},
+			wantVerbose: 1,
+		},
+		{
+			name:        "combined",
+			args:        []string{"-vvv"},
+			wantVerbose: 3,
+		},
+		{
+			name:        "combined with bool",
+			args:        []string{"-vqv"},
+			wantVerbose: 2,
+			wantQuiet:   true,
+		},
+		{
+			name:        "repeated",
+			args:        []string{"-v", "--verbose", "-vv"},
+			wantVerbose: 4,
+		},
+		{
+			name:        "explicit value",
+			args:        []string{"--verbose=3"},
+			wantVerbose: 3,
+		},
+		{
+			name:        "increment after explicit value",
+			args:        []string{"--verbose=3", "-v"},
+			wantVerbose: 4,
+		},
+		{
+			name:        "does not take the next arg",
+			args:        []string{"-v", "2"},
+			wantVerbose: 1,
+			wantArg:     "2",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			verbose := Count(&register, "verbose", WithShort("v"))
+			quiet := Bool(&register, "q")
+			arg := StringArg(&register, "arg", Optional)
+
+			if err := parser.Parse(nil, &register, tc.args); err != nil {
+				t.Fatalf("Parse(): failed to parse args: %s", err)
+			}
+
+			if *verbose != tc.wantVerbose {
+				t.Errorf("Parse(): verbose: got = %v, want = %v", *verbose, tc.wantVerbose)
+			}
+
+			assertParseBoolFlags(t, "quiet", *quiet, tc.wantQuiet)
+
+			if *arg != tc.wantArg {
+				t.Errorf("Parse(): arg: got = %q, want = %q", *arg, tc.wantArg)
+			}
+		})
+	}
+}
+
+func TestParser_Parse_count_flags_broken_value(t *testing.T) {
+	tt := []struct {
+		args []string
+		want error
+	}{
+		{
+			args: []string{"--verbose=many"},
+			want: &FlagError{Short: "v", Long: "verbose", Err: &ParseValueError{Type: "count", Err: ErrSyntax}},
+		},
+		{
+			args: []string{"--verbose=-1"},
+			want: &FlagError{Short: "v", Long: "verbose", Err: &ParseValueError{Type: "count", Err: ErrRange}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(fmt.Sprint(tc.args), func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			_ = Count(&register, "verbose", WithShort("v"))
+
+			err := parser.Parse(nil, &register, tc.args)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("Parse(): got error = %q, want error = %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestParser_Parse_optional_value_flags(t *testing.T) {
+	tt := []struct {
+		name      string
+		args      []string
+		wantColor string
+		wantLevel int
+		wantArg   string
+	}{
+		{
+			name:      "default",
+			wantColor: "never",
+		},
+		{
+			name:      "bare",
+			args:      []string{"--color"},
+			wantColor: "auto",
+		},
+		{
+			name:      "inline value",
+			args:      []string{"--color=always"},
+			wantColor: "always",
+		},
+		{
+			name:      "does not take the next arg",
+			args:      []string{"--color", "always"},
+			wantColor: "auto",
+			wantArg:   "always",
+		},
+		{
+			name:      "short",
+			args:      []string{"-c", "always"},
+			wantColor: "auto",
+			wantArg:   "always",
+		},
+		{
+			name:      "short inline value",
+			args:      []string{"-calways"},
+			wantColor: "always",
+		},
+		{
+			name:      "int",
+			args:      []string{"--level", "--color", "arg"},
+			wantColor: "auto",
+			wantLevel: 5,
+			wantArg:   "arg",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			color := String(&register, "color", WithShort("c"), OptionalValue("auto"))
+			*color = "never"
+			level := Int(&register, "level", OptionalValue("5"))
+			arg := StringArg(&register, "arg", Optional)
+
+			if err := parser.Parse(nil, &register, tc.args); err != nil {
+				t.Fatalf("Parse(): failed to parse args: %s", err)
+			}
+
+			if *color != tc.wantColor {
+				t.Errorf("Parse(): color: got = %q, want = %q", *color, tc.wantColor)
+			}
+
+			if *level != tc.wantLevel {
+				t.Errorf("Parse(): level: got = %v, want = %v", *level, tc.wantLevel)
+			}
+
+			if *arg != tc.wantArg {
+				t.Errorf("Parse(): arg: got = %q, want = %q", *arg, tc.wantArg)
+			}
+		})
+	}
+}
+
 func assertParseBoolFlags(t *testing.T, name string, got, want bool) {
 	t.Helper()
 
@@ -2249,3 +2776,67 @@ func assertParseBoolFlags(t *testing.T, name string, got, want bool) {
 		t.Errorf("Parse(): %s: got = %v, want = %v", name, got, want)
 	}
 }
+
+func TestParser_Parse_stop_at_first_arg(t *testing.T) {
+	tt := []struct {
+		name        string
+		parser      DefaultParser
+		args        []string
+		wantVerbose bool
+		wantArg     string
+		wantRest    []string
+	}{
+		{
+			name:        "interspersed",
+			parser:      DefaultParser{},
+			args:        []string{"ls", "-v", "/tmp"},
+			wantVerbose: true,
+			wantArg:     "ls",
+			wantRest:    []string{"/tmp"},
+		},
+		{
+			name:        "flags before arg",
+			parser:      DefaultParser{StopAtFirstArg: true},
+			args:        []string{"-v", "ls", "-la"},
+			wantVerbose: true,
+			wantArg:     "ls",
+			wantRest:    []string{"-la"},
+		},
+		{
+			name:     "flags after arg",
+			parser:   DefaultParser{StopAtFirstArg: true},
+			args:     []string{"ls", "-v", "--", "-la"},
+			wantArg:  "ls",
+			wantRest: []string{"-v", "--", "-la"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				rest     []string
+			)
+
+			verbose := Bool(&register, "verbose", WithShort("v"))
+			arg := StringArg(&register, "cmd")
+			_ = RestStringsVar(&register, &rest, "args")
+
+			if err := tc.parser.Parse(nil, &register, tc.args); err != nil {
+				t.Fatalf("Parse(): failed to parse args: %s", err)
+			}
+
+			if *verbose != tc.wantVerbose {
+				t.Errorf("Parse(): verbose: got = %v, want = %v", *verbose, tc.wantVerbose)
+			}
+
+			if *arg != tc.wantArg {
+				t.Errorf("Parse(): cmd: got = %q, want = %q", *arg, tc.wantArg)
+			}
+
+			if !reflect.DeepEqual(rest, tc.wantRest) {
+				t.Errorf("Parse(): args: got = %#v, want = %#v", rest, tc.wantRest)
+			}
+		})
+	}
+}
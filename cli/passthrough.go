@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrPassthroughNotSupported = errors.New("passthrough is not supported")
+
+// PassthroughArgs are arguments after "--". They are not parsed and are kept
+// separately from args and rest args.
+type PassthroughArgs struct {
+	Values *[]string
+	Name   string
+	Usage  Usager
+}
+
+type PassthroughError struct {
+	Name string
+	Err  error
+}
+
+func (e *PassthroughError) Error() string {
+	msg := "unknown error"
+	if e.Err != nil {
+		msg = e.Err.Error()
+	}
+
+	if e.Name == "" {
+		return fmt.Sprintf("cli: passthrough error: %s", msg)
+	}
+
+	return fmt.Sprintf("cli: passthrough error: '%s': %s", e.Name, msg)
+}
+
+func (e *PassthroughError) Unwrap() error { return e.Err }
+
+func (e *PassthroughError) Is(err error) bool {
+	pe, ok := err.(*PassthroughError)
+	return ok && pe.Name == e.Name && errors.Is(pe.Err, e.Err)
+}
+
+// PassthroughRegister is an optional interface of a Register. The parser puts
+// all arguments after "--" into its passthrough args if they are registered.
+type PassthroughRegister interface {
+	RegisterPassthrough(pt PassthroughArgs) error
+	Passthrough() *PassthroughArgs
+}
+
+// PassthroughVar defines passthrough args with specified name. They are shown
+// as "[-- name...]" in the help.
+func PassthroughVar(register Register, p *[]string, name string, options ...PassthroughOptionApplyer) error {
+	var opts PassthroughOptions
+	opts.applyName(name)
+	opts.applyPassthroughOptions(options)
+
+	pt := PassthroughArgs{
+		Values: p,
+		Name:   opts.Name,
+		Usage:  opts.Usage,
+	}
+
+	pr, ok := register.(PassthroughRegister)
+	if !ok {
+		return &PassthroughError{
+			Name: pt.Name,
+			Err:  ErrPassthroughNotSupported,
+		}
+	}
+
+	return pr.RegisterPassthrough(pt)
+}
+
+// Passthrough defines passthrough args with specified name.
+// The return value is the address of a []string variable that stores
+// arguments after "--". Errors are returned by Err() of the register (of the
+// command if the register does not support passthrough args).
+//
+//   command := cli.Passthrough(register, "command")
+func Passthrough(register Register, name string, options ...PassthroughOptionApplyer) *[]string {
+	p := new([]string)
+	_ = PassthroughVar(register, p, name, options...)
+	return p
+}
+
+func (r *DefaultRegister) RegisterPassthrough(pt PassthroughArgs) (err error) {
+	defer func() {
+		if err != nil && r.registerPassthroughErr == nil {
+			r.registerPassthroughErr = err
+		}
+	}()
+
+	if pt.Name == "" {
+		return &PassthroughError{Err: ErrMissingName}
+	}
+
+	if !validArg(pt.Name) {
+		return &PassthroughError{
+			Name: pt.Name,
+			Err:  ErrInvalidName,
+		}
+	}
+
+	if r.passthrough != nil {
+		return &PassthroughError{
+			Name: pt.Name,
+			Err:  ErrDuplicate,
+		}
+	}
+
+	r.passthrough = &pt
+
+	return nil
+}
+
+func (r *DefaultRegister) Passthrough() *PassthroughArgs {
+	return r.passthrough
+}
+
+func (c *Command) RegisterPassthrough(pt PassthroughArgs) error {
+	pr, ok := c.register.(PassthroughRegister)
+	if !ok {
+		err := &PassthroughError{
+			Name: pt.Name,
+			Err:  ErrPassthroughNotSupported,
+		}
+
+		// The register can't record the error, so it's returned by Err().
+		if c.err == nil {
+			c.err = err
+		}
+
+		return err
+	}
+
+	return pr.RegisterPassthrough(pt)
+}
+
+func (c *Command) Passthrough() *PassthroughArgs {
+	if pr, ok := c.register.(PassthroughRegister); ok {
+		return pr.Passthrough()
+	}
+
+	return nil
+}
+
+func passthroughOf(r Register) *PassthroughArgs {
+	if pr, ok := r.(PassthroughRegister); ok {
+		return pr.Passthrough()
+	}
+
+	return nil
+}
@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParser_Parse_passthrough(t *testing.T) {
+	tt := []struct {
+		name        string
+		parser      DefaultParser
+		posixly     bool
+		args        []string
+		wantFiles   []string
+		wantCommand []string
+		wantVerbose bool
+	}{
+		{
+			name:        "without terminator",
+			args:        []string{"a", "-v", "b"},
+			wantFiles:   []string{"a", "b"},
+			wantVerbose: true,
+		},
+		{
+			name:        "passthrough",
+			args:        []string{"a", "b", "--", "prog", "-v", "--", "c"},
+			wantFiles:   []string{"a", "b"},
+			wantCommand: []string{"prog", "-v", "--", "c"},
+		},
+		{
+			name:        "flags before passthrough",
+			args:        []string{"-v", "a", "--", "prog"},
+			wantFiles:   []string{"a"},
+			wantCommand: []string{"prog"},
+			wantVerbose: true,
+		},
+		{
+			name:        "many terminators",
+			args:        []string{"a", "--", "b", "--", "c"},
+			wantFiles:   []string{"a"},
+			wantCommand: []string{"b", "--", "c"},
+		},
+		{
+			name:        "stop at first arg",
+			parser:      DefaultParser{StopAtFirstArg: true},
+			args:        []string{"a", "-v", "--", "prog", "-x"},
+			wantFiles:   []string{"a", "-v"},
+			wantCommand: []string{"prog", "-x"},
+		},
+		{
+			name:        "posixly correct",
+			posixly:     true,
+			args:        []string{"a.txt", "-v", "--", "prog", "-x"},
+			wantFiles:   []string{"a.txt", "-v"},
+			wantCommand: []string{"prog", "-x"},
+		},
+		{
+			name:        "stop at first arg after passthrough",
+			parser:      DefaultParser{StopAtFirstArg: true},
+			args:        []string{"-v", "--", "a", "-v"},
+			wantCommand: []string{"a", "-v"},
+			wantVerbose: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				files    []string
+				command  []string
+			)
+
+			if tc.posixly {
+				_ = os.Setenv("POSIXLY_CORRECT", "")
+				defer os.Unsetenv("POSIXLY_CORRECT")
+			}
+
+			verbose := Bool(&register, "verbose", WithShort("v"))
+			_ = RestStringsVar(&register, &files, "files")
+
+			if err := PassthroughVar(&register, &command, "command"); err != nil {
+				t.Fatalf("PassthroughVar(): failed to register: %s", err)
+			}
+
+			if err := tc.parser.Parse(nil, &register, tc.args); err != nil {
+				t.Fatalf("Parse(): failed to parse args: %s", err)
+			}
+
+			if !reflect.DeepEqual(files, tc.wantFiles) {
+				t.Errorf("Parse(): files: got = %#v, want = %#v", files, tc.wantFiles)
+			}
+
+			if !reflect.DeepEqual(command, tc.wantCommand) {
+				t.Errorf("Parse(): command: got = %#v, want = %#v", command, tc.wantCommand)
+			}
+
+			if *verbose != tc.wantVerbose {
+				t.Errorf("Parse(): verbose: got = %v, want = %v", *verbose, tc.wantVerbose)
+			}
+		})
+	}
+}
+
+func TestRegisterPassthrough(t *testing.T) {
+	tt := []struct {
+		name string
+		reg  func(r Register) error
+		want error
+	}{
+		{
+			name: "missing name",
+			reg: func(r Register) error {
+				return PassthroughVar(r, new([]string), "")
+			},
+			want: &PassthroughError{Err: ErrMissingName},
+		},
+		{
+			name: "invalid name",
+			reg: func(r Register) error {
+				return PassthroughVar(r, new([]string), "-command")
+			},
+			want: &PassthroughError{Name: "-command", Err: ErrInvalidName},
+		},
+		{
+			name: "duplicate",
+			reg: func(r Register) error {
+				_ = Passthrough(r, "command")
+				return PassthroughVar(r, new([]string), "args")
+			},
+			want: &PassthroughError{Name: "args", Err: ErrDuplicate},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var register DefaultRegister
+
+			if err := tc.reg(&register); !errors.Is(err, tc.want) {
+				t.Errorf("PassthroughVar(): got error = %q, want error = %q", err, tc.want)
+			}
+
+			if err := register.Err(); !errors.Is(err, tc.want) {
+				t.Errorf("Err(): got error = %q, want error = %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestPassthroughVar_options(t *testing.T) {
+	var register DefaultRegister
+
+	err := PassthroughVar(&register, new([]string), "command",
+		Usage("command to run"),
+		PassthroughOptions{Name: "cmd"},
+	)
+	if err != nil {
+		t.Fatalf("PassthroughVar(): failed to register: %s", err)
+	}
+
+	pt := register.Passthrough()
+	if pt.Name != "cmd" {
+		t.Errorf("PassthroughVar(): name: got = %q, want = %q", pt.Name, "cmd")
+	}
+
+	if pt.Usage != Usage("command to run") {
+		t.Errorf("PassthroughVar(): usage: got = %#v, want = %#v", pt.Usage, Usage("command to run"))
+	}
+}
+
+func TestApp_Run_passthrough_not_supported(t *testing.T) {
+	// Only methods of the Register interface are promoted.
+	type plainRegister struct{ Register }
+
+	app := App{
+		Name: "test",
+		Args: []string{},
+		NewRegister: func() Register {
+			return plainRegister{&DefaultRegister{}}
+		},
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Passthrough(cmd, "command")
+
+			return func(cmd *Command) error { return nil }
+		}),
+	}
+
+	want := &PassthroughError{Name: "command", Err: ErrPassthroughNotSupported}
+	if err := app.Run(); !errors.Is(err, want) {
+		t.Errorf("Run(): got error = %q, want error = %q", err, want)
+	}
+}
+
+func TestDefaultHelper_Help_passthrough(t *testing.T) {
+	app := App{
+		Name: "passthrough",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = RestStrings(cmd, "files")
+			_ = Passthrough(cmd, "command")
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+	}
+
+	cmd, err := app.Command("passthrough")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := (DefaultHelper{}).Help(cmd, &buf); err != nil {
+		t.Fatalf("Help(): failed to write help: %s", err)
+	}
+
+	want := "Usage: passthrough [files...] [-- command...]\n"
+	if got := buf.String(); !strings.HasPrefix(got, want) {
+		t.Errorf("Help(): got = %q, want prefix = %q", got, want)
+	}
+}
@@ -0,0 +1,228 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+var (
+	ErrNestingTooDeep = errors.New("nesting too deep")
+
+	ErrUnterminatedQuote = errors.New("unterminated quote")
+)
+
+// MaxResponseFileDepth is the maximum nesting of response files.
+const MaxResponseFileDepth = 16
+
+type ResponseFileError struct {
+	File string
+	Line int // 0 if the error is not related to a line.
+	Err  error
+}
+
+func (e *ResponseFileError) Error() string {
+	msg := "unknown error"
+	if e.Err != nil {
+		msg = e.Err.Error()
+	}
+
+	return fmt.Sprintf("cli: response file error: '%s': %s", e.Location(), msg)
+}
+
+func (e *ResponseFileError) Unwrap() error { return e.Err }
+
+func (e *ResponseFileError) Is(err error) bool {
+	re, ok := err.(*ResponseFileError)
+	return ok && re.File == e.File && re.Line == e.Line && errors.Is(re.Err, e.Err)
+}
+
+// Location returns the file and the line in the "file:line" form.
+func (e *ResponseFileError) Location() string {
+	if e.Line == 0 {
+		return e.File
+	}
+
+	return fmt.Sprintf("%s:%d", e.File, e.Line)
+}
+
+// expandResponseFiles replaces "@path" arguments with arguments from files.
+// "@@arg" is an escaped "@arg". Arguments after "--" are not expanded.
+//
+// Errors of nested response files are reported at the line of the file which
+// references them.
+func (p *DefaultParser) expandResponseFiles(arguments []string) ([]string, error) {
+	var terminated bool
+
+	// The parent is the file of arguments ("" for the command line) and
+	// lines are lines of arguments in the file.
+	var expand func(parent string, arguments []string, lines []int, depth int) ([]string, error)
+	expand = func(parent string, arguments []string, lines []int, depth int) ([]string, error) {
+		fileError := func(i int, file string, err error) error {
+			if parent == "" {
+				return &ResponseFileError{
+					File: file,
+					Err:  err,
+				}
+			}
+
+			return &ResponseFileError{
+				File: parent,
+				Line: lines[i],
+				Err:  err,
+			}
+		}
+
+		res := make([]string, 0, len(arguments))
+		for i, arg := range arguments {
+			switch {
+			case terminated || len(arg) < 2 || arg[0] != '@':
+				res = append(res, arg)
+
+				if arg == "--" {
+					terminated = true
+				}
+
+			case arg[1] == '@':
+				res = append(res, arg[1:])
+
+			default:
+				file := arg[1:]
+				if depth >= MaxResponseFileDepth {
+					return nil, fileError(i, file, ErrNestingTooDeep)
+				}
+
+				data, err := ioutil.ReadFile(file)
+				if err != nil {
+					return nil, fileError(i, file, err)
+				}
+
+				args, argLines, line, err := splitResponseFile(string(data))
+				if err != nil {
+					return nil, &ResponseFileError{
+						File: file,
+						Line: line,
+						Err:  err,
+					}
+				}
+
+				args, err = expand(file, args, argLines, depth+1)
+				if err != nil {
+					return nil, err
+				}
+
+				res = append(res, args...)
+			}
+		}
+
+		return res, nil
+	}
+
+	return expand("", arguments, nil, 0)
+}
+
+// splitResponseFile splits the content of a response file into arguments
+// like a shell does: arguments are separated by whitespaces, quotes and
+// backslashes escape characters, "#" starts a comment.
+//
+// It returns lines where arguments start. On error it returns the line where
+// the error has occurred.
+func splitResponseFile(s string) (args []string, lines []int, line int, err error) {
+	var (
+		buf       strings.Builder
+		inArg     bool
+		argLine   int
+		quote     byte
+		quoteLine int
+	)
+
+	startArg := func() {
+		if !inArg {
+			argLine = line
+			inArg = true
+		}
+	}
+
+	line = 1
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		if c == '\n' {
+			line++
+		}
+
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			} else {
+				_ = buf.WriteByte(c)
+			}
+
+		case quote == '"':
+			switch {
+			case c == '"':
+				quote = 0
+
+			case c == '\\' && i+1 < len(s) && strings.IndexByte("\"\\$`\n", s[i+1]) != -1:
+				i++
+				if s[i] == '\n' {
+					line++
+				} else {
+					_ = buf.WriteByte(s[i])
+				}
+
+			default:
+				_ = buf.WriteByte(c)
+			}
+
+		case c == '\'' || c == '"':
+			quote = c
+			quoteLine = line
+			startArg()
+
+		case c == '\\':
+			if i+1 < len(s) {
+				i++
+				if s[i] == '\n' {
+					// Line continuation.
+					line++
+					continue
+				}
+
+				startArg()
+				_ = buf.WriteByte(s[i])
+			}
+
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if inArg {
+				args = append(args, buf.String())
+				lines = append(lines, argLine)
+				buf.Reset()
+				inArg = false
+			}
+
+		case c == '#' && !inArg:
+			// Skip the comment.
+			for i+1 < len(s) && s[i+1] != '\n' {
+				i++
+			}
+
+		default:
+			startArg()
+			_ = buf.WriteByte(c)
+		}
+	}
+
+	if quote != 0 {
+		return nil, nil, quoteLine, ErrUnterminatedQuote
+	}
+
+	if inArg {
+		args = append(args, buf.String())
+		lines = append(lines, argLine)
+	}
+
+	return args, lines, 0, nil
+}
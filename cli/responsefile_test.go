@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitResponseFile(t *testing.T) {
+	tt := []struct {
+		name string
+		s    string
+		want []string
+	}{
+		{
+			name: "empty",
+			s:    "",
+			want: nil,
+		},
+		{
+			name: "whitespaces",
+			s:    "  -a\t--b  c\n\nd\r\n",
+			want: []string{"-a", "--b", "c", "d"},
+		},
+		{
+			name: "single quotes",
+			s:    `'a b' 'c"d' '' 'e\f'`,
+			want: []string{"a b", `c"d`, "", `e\f`},
+		},
+		{
+			name: "double quotes",
+			s:    `"a b" "c'd" "e\"f" "g\h" "i\\j"`,
+			want: []string{"a b", "c'd", `e"f`, `g\h`, `i\j`},
+		},
+		{
+			name: "backslashes",
+			s:    `a\ b c\"d e\\f`,
+			want: []string{"a b", `c"d`, `e\f`},
+		},
+		{
+			name: "line continuation",
+			s:    "a \\\nb",
+			want: []string{"a", "b"},
+		},
+		{
+			name: "comments",
+			s:    "# comment\n-a # comment\nb#c",
+			want: []string{"-a", "b#c"},
+		},
+		{
+			name: "multiline quotes",
+			s:    "'a\nb'",
+			want: []string{"a\nb"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, _, _, err := splitResponseFile(tc.s)
+			if err != nil {
+				t.Fatalf("splitResponseFile(): failed to split: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("splitResponseFile(): got = %#v, want = %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSplitResponseFile_lines(t *testing.T) {
+	_, got, _, err := splitResponseFile("a b\n\n# comment\n'c\nd' \\\ne\n  f")
+	if err != nil {
+		t.Fatalf("splitResponseFile(): failed to split: %s", err)
+	}
+
+	want := []int{1, 1, 4, 6, 7}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("splitResponseFile(): lines: got = %#v, want = %#v", got, want)
+	}
+}
+
+func TestSplitResponseFile_unterminated_quote(t *testing.T) {
+	_, _, line, err := splitResponseFile("a\nb 'c\nd")
+	if !errors.Is(err, ErrUnterminatedQuote) {
+		t.Fatalf("splitResponseFile(): got error = %q, want error = %q", err, ErrUnterminatedQuote)
+	}
+
+	if line != 2 {
+		t.Errorf("splitResponseFile(): line: got = %v, want = %v", line, 2)
+	}
+}
+
+func TestApp_Run_response_files(t *testing.T) {
+	dir := writeTempFiles(t, map[string]string{
+		"build.rsp":  "build --jobs 4\n@$DIR/nested.rsp",
+		"nested.rsp": "'src dir' @@literal",
+	})
+	defer os.RemoveAll(dir)
+
+	var (
+		jobs    int
+		rest    []string
+		command string
+	)
+
+	app := App{
+		Name:   "test",
+		Args:   []string{"@" + filepath.Join(dir, "build.rsp"), "last", "--", "@tail"},
+		Parser: &DefaultParser{ResponseFiles: true},
+		Commands: []Command{
+			{
+				Name: "build",
+				Action: ActionFunc(func(cmd *Command) ActionRunner {
+					_ = IntVar(cmd, &jobs, "jobs")
+					_ = RestStringsVar(cmd, &rest, "files")
+
+					return func(cmd *Command) error {
+						command = cmd.Name
+						return nil
+					}
+				}),
+			},
+		},
+	}
+
+	if err := app.Run(); err != nil {
+		t.Fatalf("Run(): failed to run: %s", err)
+	}
+
+	if command != "build" {
+		t.Errorf("Run(): command: got = %q, want = %q", command, "build")
+	}
+
+	if jobs != 4 {
+		t.Errorf("Run(): jobs: got = %v, want = %v", jobs, 4)
+	}
+
+	want := []string{"src dir", "@literal", "last", "@tail"}
+	if !reflect.DeepEqual(rest, want) {
+		t.Errorf("Run(): rest: got = %#v, want = %#v", rest, want)
+	}
+}
+
+func TestParser_Parse_response_files_errors(t *testing.T) {
+	dir := writeTempFiles(t, map[string]string{
+		"quote.rsp":   "-a\n'b",
+		"loop.rsp":    "-a\n@$DIR/loop.rsp",
+		"missing.rsp": "-a\n\n@$DIR/none.rsp",
+	})
+	defer os.RemoveAll(dir)
+
+	tt := []struct {
+		name string
+		args []string
+		want *ResponseFileError
+	}{
+		{
+			name: "unterminated quote",
+			args: []string{"@" + filepath.Join(dir, "quote.rsp")},
+			want: &ResponseFileError{File: filepath.Join(dir, "quote.rsp"), Line: 2, Err: ErrUnterminatedQuote},
+		},
+		{
+			name: "nesting",
+			args: []string{"@" + filepath.Join(dir, "loop.rsp")},
+			want: &ResponseFileError{File: filepath.Join(dir, "loop.rsp"), Line: 2, Err: ErrNestingTooDeep},
+		},
+		{
+			name: "missing",
+			args: []string{"@" + filepath.Join(dir, "none.rsp")},
+			want: &ResponseFileError{File: filepath.Join(dir, "none.rsp"), Err: os.ErrNotExist},
+		},
+		{
+			name: "missing nested",
+			args: []string{"@" + filepath.Join(dir, "missing.rsp")},
+			want: &ResponseFileError{File: filepath.Join(dir, "missing.rsp"), Line: 3, Err: os.ErrNotExist},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   = DefaultParser{ResponseFiles: true}
+			)
+
+			_ = Bool(&register, "a")
+			_ = RestStrings(&register, "rest")
+
+			err := parser.Parse(nil, &register, tc.args)
+
+			var got *ResponseFileError
+			if !errors.As(err, &got) || got.File != tc.want.File || got.Line != tc.want.Line || !errors.Is(got.Err, tc.want.Err) {
+				t.Fatalf("Parse(): got error = %q, want error = %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestParser_Parse_response_files_disabled(t *testing.T) {
+	var (
+		register DefaultRegister
+		parser   DefaultParser
+	)
+
+	rest := RestStrings(&register, "rest")
+
+	if err := parser.Parse(nil, &register, []string{"@file", "@@file"}); err != nil {
+		t.Fatalf("Parse(): failed to parse args: %s", err)
+	}
+
+	want := []string{"@file", "@@file"}
+	if !reflect.DeepEqual(*rest, want) {
+		t.Errorf("Parse(): rest: got = %#v, want = %#v", *rest, want)
+	}
+}
+
+func TestApp_handleError_response_file(t *testing.T) {
+	var (
+		app App
+		buf strings.Builder
+	)
+
+	_ = app.handleError(&ResponseFileError{File: "args.rsp", Line: 3, Err: ErrUnterminatedQuote}, &buf)
+
+	assertStringsDiff(t, buf.String(), "Unable to read the response file args.rsp:3: unterminated quote\n")
+}
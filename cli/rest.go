@@ -5,6 +5,10 @@ type RestArgs struct {
 	Name   string
 	Usage  Usager
 
+	Validators []Validator
+
+	set bool
+
 	defaultSaved bool
 	defaultValue string
 	defaultEmpty bool
@@ -15,6 +19,8 @@ func newRest(values Value, opts RestOptions) RestArgs {
 		Values: values,
 		Name:   opts.Name,
 		Usage:  opts.Usage,
+
+		Validators: opts.Validators,
 	}
 }
 
@@ -37,11 +43,22 @@ func (ra *RestArgs) Add(val string) error {
 		if err := ra.Values.Set(val); err != nil {
 			return err
 		}
+
+		if err := validateValue(ra.Values, ra.Validators); err != nil {
+			return err
+		}
 	}
 
+	ra.set = true
+
 	return nil
 }
 
+// Set reports whether at least one rest arg was added.
+func (ra *RestArgs) Set() bool {
+	return ra.set
+}
+
 func (ra *RestArgs) Default() (v string, empty bool) {
 	if !ra.defaultSaved {
 		return "", true
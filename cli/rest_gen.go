@@ -3,6 +3,8 @@
 package cli
 
 import (
+	"net"
+	"net/url"
 	"time"
 )
 
@@ -395,3 +397,81 @@ func RestDurations(register Register, name string, options ...RestOptionApplyer)
 	_ = RestDurationsVar(register, p, name, options...)
 	return p
 }
+
+// []net.IP
+
+// RestIPsVar defines the []net.IP rest arguments with specified name.
+// The argument p points to a []net.IP variable in which to store values of arguments.
+// The return value will be an error from the register.RegisterRestArgs if it
+// failed to register the rest arguments.
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.RestIPsVar(register, &p, "names", cli.Usage("Names of users"))
+func RestIPsVar(register Register, p *[]net.IP, name string, options ...RestOptionApplyer) error {
+	return RestVar(register, newIPValues(p), name, options...)
+}
+
+// RestIPs defines the []net.IP rest arguments with specified name.
+// The return value is the address of a []net.IP variable that stores values of arguments.
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.RestIPs(register, "names", cli.Usage("Names of users"))
+func RestIPs(register Register, name string, options ...RestOptionApplyer) *[]net.IP {
+	p := new([]net.IP)
+	_ = RestIPsVar(register, p, name, options...)
+	return p
+}
+
+// []net.IPNet
+
+// RestIPNetsVar defines the []net.IPNet rest arguments with specified name.
+// The argument p points to a []net.IPNet variable in which to store values of arguments.
+// The return value will be an error from the register.RegisterRestArgs if it
+// failed to register the rest arguments.
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.RestIPNetsVar(register, &p, "names", cli.Usage("Names of users"))
+func RestIPNetsVar(register Register, p *[]net.IPNet, name string, options ...RestOptionApplyer) error {
+	return RestVar(register, newIPNetValues(p), name, options...)
+}
+
+// RestIPNets defines the []net.IPNet rest arguments with specified name.
+// The return value is the address of a []net.IPNet variable that stores values of arguments.
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.RestIPNets(register, "names", cli.Usage("Names of users"))
+func RestIPNets(register Register, name string, options ...RestOptionApplyer) *[]net.IPNet {
+	p := new([]net.IPNet)
+	_ = RestIPNetsVar(register, p, name, options...)
+	return p
+}
+
+// []url.URL
+
+// RestURLsVar defines the []url.URL rest arguments with specified name.
+// The argument p points to a []url.URL variable in which to store values of arguments.
+// The return value will be an error from the register.RegisterRestArgs if it
+// failed to register the rest arguments.
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.RestURLsVar(register, &p, "names", cli.Usage("Names of users"))
+func RestURLsVar(register Register, p *[]url.URL, name string, options ...RestOptionApplyer) error {
+	return RestVar(register, newURLValues(p), name, options...)
+}
+
+// RestURLs defines the []url.URL rest arguments with specified name.
+// The return value is the address of a []url.URL variable that stores values of arguments.
+//
+// A usage may be set by passing a cli.Usage.
+//
+//   _ = cli.RestURLs(register, "names", cli.Usage("Names of users"))
+func RestURLs(register Register, name string, options ...RestOptionApplyer) *[]url.URL {
+	p := new([]url.URL)
+	_ = RestURLsVar(register, p, name, options...)
+	return p
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"io/ioutil"
+	"strings"
+)
+
+// sensitiveMask replaces values of sensitive flags.
+const sensitiveMask = "******"
+
+// flagFileValue returns the value of a FromFile flag: the content of the file
+// for the "--<long>-file path" form and "@path" values, or the value itself.
+func flagFileValue(value string, fileForm bool) (string, error) {
+	switch {
+	case fileForm:
+		return readValueFile(value)
+
+	case strings.HasPrefix(value, "@@"):
+		return value[1:], nil
+
+	case strings.HasPrefix(value, "@"):
+		return readValueFile(value[1:])
+
+	default:
+		return value, nil
+	}
+}
+
+// readValueFile reads a value from the file without the trailing newline.
+func readValueFile(name string) (string, error) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+
+	value := strings.TrimSuffix(string(data), "\n")
+	value = strings.TrimSuffix(value, "\r")
+
+	return value, nil
+}
+
+// sensitiveError hides the message of the error, which may contain the value.
+// The original error is available via Unwrap.
+type sensitiveError struct {
+	err error
+}
+
+func (e *sensitiveError) Error() string { return "invalid value" }
+
+func (e *sensitiveError) Unwrap() error { return e.err }
+
+// maskError hides the value of a sensitive flag in the error. Errors can still
+// be matched with errors.Is.
+func (f *Flag) maskError(err error) error {
+	if err == nil || !f.Sensitive {
+		return err
+	}
+
+	if pe, ok := err.(*ParseValueError); ok {
+		return &ParseValueError{
+			Type: pe.Type,
+			Err:  &sensitiveError{err: pe.Err},
+		}
+	}
+
+	return &sensitiveError{err: err}
+}
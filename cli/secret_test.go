@@ -0,0 +1,389 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParser_Parse_from_file(t *testing.T) {
+	dir := writeTempFiles(t, map[string]string{
+		"token":      "s3cr3t\n",
+		"token.crlf": "s3cr3t\r\n",
+		"multiline":  "a\nb\n\n",
+	})
+	defer os.RemoveAll(dir)
+
+	tt := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "plain value",
+			args: []string{"--token", "s3cr3t"},
+			want: "s3cr3t",
+		},
+		{
+			name: "at",
+			args: []string{"--token=@" + filepath.Join(dir, "token")},
+			want: "s3cr3t",
+		},
+		{
+			name: "at next argument",
+			args: []string{"--token", "@" + filepath.Join(dir, "token.crlf")},
+			want: "s3cr3t",
+		},
+		{
+			name: "escaped at",
+			args: []string{"--token=@@s3cr3t"},
+			want: "@s3cr3t",
+		},
+		{
+			name: "file form",
+			args: []string{"--token-file", filepath.Join(dir, "token")},
+			want: "s3cr3t",
+		},
+		{
+			name: "file form inline",
+			args: []string{"--token-file=" + filepath.Join(dir, "multiline")},
+			want: "a\nb\n",
+		},
+		{
+			name: "file form of alias",
+			args: []string{"--api-token-file", filepath.Join(dir, "token")},
+			want: "s3cr3t",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			token := String(&register, "token", WithAlias("api-token"), FromFile)
+
+			if err := parser.Parse(nil, &register, tc.args); err != nil {
+				t.Fatalf("Parse(): failed to parse args: %s", err)
+			}
+
+			if *token != tc.want {
+				t.Errorf("Parse(): token: got = %q, want = %q", *token, tc.want)
+			}
+		})
+	}
+}
+
+func TestApp_Run_from_file_env_config(t *testing.T) {
+	dir := writeTempFiles(t, map[string]string{
+		"token":      "s3cr3t\n",
+		"key":        "k3y\n",
+		"config.ini": "key = @$DIR/key\nname = @@name\n",
+	})
+	defer os.RemoveAll(dir)
+
+	var token, key, name string
+
+	app := App{
+		Name: "test",
+		Args: []string{},
+		LookupEnv: func(key string) (string, bool) {
+			if key == "TOKEN" {
+				return "@" + filepath.Join(dir, "token"), true
+			}
+
+			return "", false
+		},
+		Config: &Config{
+			Files: []string{filepath.Join(dir, "config.ini")},
+		},
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = StringVar(cmd, &token, "token", WithEnv("TOKEN"), FromFile)
+			_ = StringVar(cmd, &key, "key", FromFile)
+			_ = StringVar(cmd, &name, "name", FromFile)
+
+			return func(cmd *Command) error { return nil }
+		}),
+	}
+
+	if err := app.Run(); err != nil {
+		t.Fatalf("Run(): failed to run: %s", err)
+	}
+
+	if token != "s3cr3t" {
+		t.Errorf("Run(): token: got = %q, want = %q", token, "s3cr3t")
+	}
+
+	if key != "k3y" {
+		t.Errorf("Run(): key: got = %q, want = %q", key, "k3y")
+	}
+
+	if name != "@name" {
+		t.Errorf("Run(): name: got = %q, want = %q", name, "@name")
+	}
+}
+
+func TestParser_Parse_from_file_errors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cli-test")
+	if err != nil {
+		t.Fatalf("TempDir(): failed to create a directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tt := []struct {
+		name string
+		args []string
+	}{
+		{
+			name: "at",
+			args: []string{"--token=@" + filepath.Join(dir, "missing")},
+		},
+		{
+			name: "file form",
+			args: []string{"--token-file", filepath.Join(dir, "missing")},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			_ = String(&register, "token", FromFile)
+
+			err := parser.Parse(nil, &register, tc.args)
+
+			var flagErr *FlagError
+			if !errors.As(err, &flagErr) || flagErr.Long != "token" {
+				t.Fatalf("Parse(): got error = %q, want FlagError for token", err)
+			}
+
+			if !errors.Is(flagErr.Err, os.ErrNotExist) {
+				t.Errorf("Parse(): got error = %q, want error = %q", flagErr.Err, os.ErrNotExist)
+			}
+		})
+	}
+}
+
+func TestRegisterFlag_from_file_duplicate(t *testing.T) {
+	var register DefaultRegister
+
+	_ = String(&register, "token", FromFile)
+	_ = String(&register, "token-file")
+
+	want := &FlagError{Long: "token-file", Err: ErrDuplicate}
+	if err := register.Err(); !errors.Is(err, want) {
+		t.Errorf("Err(): got error = %q, want error = %q", err, want)
+	}
+}
+
+func TestParser_Parse_sensitive(t *testing.T) {
+	const secret = "hunter2"
+
+	tt := []struct {
+		name    string
+		args    []string
+		env     map[string]string
+		wantErr error
+	}{
+		{
+			name:    "flag",
+			args:    []string{"--password", secret},
+			wantErr: &FlagError{Long: "password", Err: &ParseValueError{Type: "string", Err: ErrTooShort}},
+		},
+		{
+			name:    "env",
+			env:     map[string]string{"PASSWORD": secret},
+			wantErr: &FlagError{Long: "password", Env: "PASSWORD", Err: &ParseValueError{Type: "string", Err: ErrTooShort}},
+		},
+		{
+			name:    "choice",
+			args:    []string{"--mode", secret},
+			wantErr: &FlagError{Long: "mode", Err: &ParseValueError{Type: "choice", Err: ErrInvalidChoice}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			app := App{
+				Name: "test",
+				Args: append([]string{}, tc.args...),
+				LookupEnv: func(key string) (string, bool) {
+					v, ok := tc.env[key]
+					return v, ok
+				},
+				Action: ActionFunc(func(cmd *Command) ActionRunner {
+					_ = String(cmd, "password", WithEnv("PASSWORD"), MinLen(12), Sensitive)
+					_ = Choice(cmd, "mode", ChoicesOf("a", "b"), Sensitive)
+
+					return func(cmd *Command) error { return nil }
+				}),
+			}
+
+			err := app.Run()
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Run(): got error = %q, want error = %q", err, tc.wantErr)
+			}
+
+			if strings.Contains(err.Error(), secret) {
+				t.Errorf("Run(): error contains the secret: %q", err)
+			}
+
+			var buf strings.Builder
+			_ = app.handleError(err, &buf)
+			if strings.Contains(buf.String(), secret) {
+				t.Errorf("handleError(): output contains the secret: %q", buf.String())
+			}
+		})
+	}
+}
+
+func TestParser_Parse_sensitive_message(t *testing.T) {
+	const secret = "a"
+
+	errBadToken := fmt.Errorf("bad token %q", secret)
+
+	tt := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "parse error",
+			args:    []string{"--pin", secret},
+			want:    "cli: flag error: 'pin': parse int error: invalid value",
+			wantErr: ErrSyntax,
+		},
+		{
+			name:    "non-wrapping error",
+			args:    []string{"--token", secret},
+			want:    "cli: flag error: 'token': parse string error: invalid value",
+			wantErr: errBadToken,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			_ = Int(&register, "pin", Sensitive)
+			_ = String(&register, "token", Sensitive, Validate(func(v interface{}) error {
+				if v.(string) == secret {
+					return errBadToken
+				}
+
+				return nil
+			}))
+
+			err := parser.Parse(nil, &register, tc.args)
+			if err == nil {
+				t.Fatalf("Parse(): expected an error")
+			}
+
+			if got := err.Error(); got != tc.want {
+				t.Errorf("Parse(): got error = %q, want error = %q", got, tc.want)
+			}
+
+			// The original error is still available.
+			var (
+				fe *FlagError
+				se *sensitiveError
+			)
+			if !errors.As(err, &fe) || !errors.As(fe.Err, &se) || !errors.Is(se.err, tc.wantErr) {
+				t.Errorf("Parse(): got error = %#v, want error = %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestFlag_DisplayValue(t *testing.T) {
+	var register DefaultRegister
+
+	_ = String(&register, "user")
+	_ = String(&register, "password", Sensitive)
+
+	if err := (&DefaultParser{}).Parse(nil, &register, []string{"--user", "admin", "--password", "hunter2"}); err != nil {
+		t.Fatalf("Parse(): failed to parse args: %s", err)
+	}
+
+	tt := []struct {
+		name string
+		want string
+	}{
+		{"user", "admin"},
+		{"password", sensitiveMask},
+	}
+
+	for _, tc := range tt {
+		flag, ok := register.LongFlag(tc.name)
+		if !ok {
+			t.Fatalf("LongFlag(%q): flag not found", tc.name)
+		}
+
+		if got := flag.DisplayValue(); got != tc.want {
+			t.Errorf("DisplayValue(): %s: got = %q, want = %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestDefaultHelper_Help_sensitive(t *testing.T) {
+	app := App{
+		Name: "secret",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			token := "default-token"
+			_ = StringVar(cmd, &token, "token", FromFile, Sensitive, Usage("API token"))
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+	}
+
+	cmd, err := app.Command("secret")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := (DefaultHelper{}).Help(cmd, &buf); err != nil {
+		t.Fatalf("Help(): failed to write help: %s", err)
+	}
+
+	got := buf.String()
+
+	assertContains(t, got,
+		"API token (default: ******, file: --token-file)",
+	)
+
+	if strings.Contains(got, "default-token") {
+		t.Errorf("Help(): help contains the sensitive default: %q", got)
+	}
+}
+
+func TestZSHCompletionGenerator_from_file(t *testing.T) {
+	app := &App{
+		Name: "test",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = String(cmd, "token", FromFile)
+
+			return nil
+		}),
+	}
+
+	got := generateZSHCompletion(t, app)
+
+	assertContains(t, got,
+		`--token'='': :()'`,
+		`--token-file'=: :_files'`,
+	)
+}
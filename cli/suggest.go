@@ -0,0 +1,128 @@
+package cli
+
+import "sort"
+
+const maxSuggestionDistance = 2
+
+type suggestion struct {
+	value    string
+	distance int
+	order    int
+}
+
+// suggester collects candidates similar to the name for "Did you mean?"
+// hints.
+type suggester struct {
+	name        string
+	suggestions []suggestion
+	seen        map[string]bool
+}
+
+func newSuggester(name string) *suggester {
+	return &suggester{name: name}
+}
+
+// Add compares the candidate with the name and, if they are similar, saves
+// the value (e.g. a formatted flag name) as a suggestion.
+func (s *suggester) Add(candidate, value string) {
+	if candidate == "" || s.name == "" || s.seen[value] {
+		return
+	}
+
+	distance := editDistance(s.name, candidate)
+
+	similar := distance <= maxSuggestionDistance &&
+		distance < len(candidate) && distance < len(s.name)
+
+	// "--ver" -> "--verbose".
+	prefix := len(s.name) > 1 && len(s.name) < len(candidate) &&
+		candidate[:len(s.name)] == s.name
+
+	if !similar && !prefix {
+		return
+	}
+
+	if s.seen == nil {
+		s.seen = make(map[string]bool)
+	}
+	s.seen[value] = true
+
+	s.suggestions = append(s.suggestions, suggestion{
+		value:    value,
+		distance: distance,
+		order:    len(s.suggestions),
+	})
+}
+
+// Suggestions returns values ranked by similarity (the most similar first).
+func (s *suggester) Suggestions() []string {
+	if len(s.suggestions) == 0 {
+		return nil
+	}
+
+	sort.Slice(s.suggestions, func(i, j int) bool {
+		a, b := &s.suggestions[i], &s.suggestions[j]
+		if a.distance != b.distance {
+			return a.distance < b.distance
+		}
+
+		return a.order < b.order
+	})
+
+	res := make([]string, len(s.suggestions))
+	for i := range s.suggestions {
+		res[i] = s.suggestions[i].value
+	}
+
+	return res
+}
+
+// editDistance returns the optimal string alignment distance between a and b
+// (the Levenshtein distance with transpositions of two adjacent bytes).
+func editDistance(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	// We need only three rows of the matrix.
+	prevPrev := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			d := min(prev[j]+1, curr[j-1]+1)
+			d = min(d, prev[j-1]+cost)
+
+			// Transposition: "jbos" -> "jobs".
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d = min(d, prevPrev[j-2]+1)
+			}
+
+			curr[j] = d
+		}
+
+		prevPrev, prev, curr = prev, curr, prevPrev
+	}
+
+	return prev[len(b)]
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tt := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "jobs", b: "jobs", want: 0},
+		{a: "", b: "jobs", want: 4},
+		{a: "jobs", b: "", want: 4},
+		{a: "jbos", b: "jobs", want: 1},
+		{a: "job", b: "jobs", want: 1},
+		{a: "jobz", b: "jobs", want: 1},
+		{a: "clnoe", b: "clone", want: 1},
+		{a: "kitten", b: "sitting", want: 3},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.a+"/"+tc.b, func(t *testing.T) {
+			got := editDistance(tc.a, tc.b)
+			if got != tc.want {
+				t.Errorf("editDistance(%q, %q): got = %d, want = %d", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSuggester(t *testing.T) {
+	tt := []struct {
+		name       string
+		candidates []string
+		want       []string
+	}{
+		{
+			name:       "jbos",
+			candidates: []string{"verbose", "jobs", "job", "j"},
+			want:       []string{"jobs", "job"},
+		},
+		{
+			name:       "ver",
+			candidates: []string{"version", "verbose", "v"},
+			want:       []string{"version", "verbose"},
+		},
+		{
+			name:       "x",
+			candidates: []string{"y", "z", "xyz"},
+			want:       nil,
+		},
+		{
+			name:       "stats",
+			candidates: []string{"status", "start", "stash", "commit"},
+			want:       []string{"status", "start", "stash"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newSuggester(tc.name)
+			for _, c := range tc.candidates {
+				s.Add(c, c)
+			}
+
+			got := s.Suggestions()
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Suggestions(): got = %#v, want = %#v", got, tc.want)
+			}
+		})
+	}
+}
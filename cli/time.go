@@ -0,0 +1,480 @@
+package cli
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+// durationUnits are the units understood by time.ParseDuration.
+var durationUnits = map[string]bool{
+	"ns": true,
+	"us": true,
+	"µs": true, // U+00B5 micro sign.
+	"μs": true, // U+03BC Greek letter mu.
+	"ms": true,
+	"s":  true,
+	"m":  true,
+	"h":  true,
+}
+
+// timeNow returns the current time. It is replaced in tests.
+var timeNow = time.Now
+
+// parseDuration is like time.ParseDuration but also understands the "d" (24h)
+// and "w" (7d) units, e.g. "1w2d12h".
+func parseDuration(s string) (time.Duration, error) {
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	if s == "0" {
+		return 0, nil
+	}
+
+	if s == "" {
+		return 0, ErrSyntax
+	}
+
+	var d time.Duration
+	for s != "" {
+		i := 0
+		for i < len(s) && (s[i] == '.' || ('0' <= s[i] && s[i] <= '9')) {
+			i++
+		}
+
+		j := i
+		for j < len(s) && s[j] != '.' && (s[j] < '0' || s[j] > '9') {
+			j++
+		}
+
+		num, unit := s[:i], s[i:j]
+		s = s[j:]
+
+		var part time.Duration
+		switch unit {
+		case "d", "w":
+			f, err := strconv.ParseFloat(num, 64)
+			if err != nil {
+				return 0, ErrSyntax
+			}
+
+			mult := day
+			if unit == "w" {
+				mult = week
+			}
+
+			// float64(math.MaxInt64) is 2^63 which overflows time.Duration.
+			v := f * float64(mult)
+			if v >= math.MaxInt64 {
+				return 0, ErrRange
+			}
+
+			part = time.Duration(v)
+
+		default:
+			v, err := time.ParseDuration(num + unit)
+			if err != nil {
+				// time.ParseDuration doesn't tell an overflow from a
+				// syntax error.
+				if durationUnits[unit] {
+					if _, err := strconv.ParseFloat(num, 64); err == nil {
+						return 0, ErrRange
+					}
+				}
+
+				return 0, ErrSyntax
+			}
+
+			part = v
+		}
+
+		if d > math.MaxInt64-part {
+			return 0, ErrRange
+		}
+
+		d += part
+	}
+
+	if neg {
+		d = -d
+	}
+
+	return d, nil
+}
+
+// Extended duration.
+
+var (
+	_ Value   = (*longDurationValue)(nil)
+	_ Getter  = (*longDurationValue)(nil)
+	_ Emptier = (*longDurationValue)(nil)
+	_ Typer   = (*longDurationValue)(nil)
+)
+
+type longDurationValue time.Duration
+
+func newLongDurationValue(p *time.Duration) *longDurationValue {
+	return (*longDurationValue)(p)
+}
+
+func (d *longDurationValue) Set(s string) error {
+	v, err := parseDuration(s)
+	if err != nil {
+		return &ParseValueError{
+			Type: "time.Duration",
+			Err:  err,
+		}
+	}
+
+	*d = longDurationValue(v)
+	return nil
+}
+
+func (d *longDurationValue) Get() interface{} { return time.Duration(*d) }
+
+func (d *longDurationValue) Empty() bool { return *d == 0 }
+
+func (d *longDurationValue) String() string { return (*time.Duration)(d).String() }
+
+func (*longDurationValue) Type() string { return "time.Duration" }
+
+var (
+	_ Value      = (*longDurationValues)(nil)
+	_ Getter     = (*longDurationValues)(nil)
+	_ Emptier    = (*longDurationValues)(nil)
+	_ Typer      = (*longDurationValues)(nil)
+	_ sliceValue = (*longDurationValues)(nil)
+)
+
+type longDurationValues []time.Duration
+
+func newLongDurationValues(p *[]time.Duration) *longDurationValues {
+	return (*longDurationValues)(p)
+}
+
+func (vs *longDurationValues) Set(val string) error {
+	for _, s := range strings.Split(val, ",") {
+		if s == "" {
+			continue
+		}
+
+		var v time.Duration
+		if err := newLongDurationValue(&v).Set(s); err != nil {
+			return err
+		}
+
+		*vs = append(*vs, v)
+	}
+
+	return nil
+}
+
+func (vs *longDurationValues) Get() interface{} { return []time.Duration(*vs) }
+
+func (vs *longDurationValues) Empty() bool { return len(*vs) == 0 }
+
+func (vs *longDurationValues) String() string {
+	values := make([]string, len(*vs))
+	for i, v := range *vs {
+		values[i] = v.String()
+	}
+
+	return strings.Join(values, ",")
+}
+
+func (*longDurationValues) Type() string { return "[]time.Duration" }
+
+func (*longDurationValues) IsSliceValue() bool { return true }
+
+// LongDurationVar defines a time.Duration flag with specified name. Unlike
+// DurationVar, it also understands the "d" (24h) and "w" (7d) units.
+func LongDurationVar(register Register, p *time.Duration, name string, options ...FlagOptionApplyer) error {
+	return Var(register, newLongDurationValue(p), name, options...)
+}
+
+// LongDuration defines a time.Duration flag with specified name. Unlike
+// Duration, it also understands the "d" (24h) and "w" (7d) units.
+// The return value is the address of a time.Duration variable that stores the
+// value of the flag.
+//
+//   retention := cli.LongDuration(register, "retention") // --retention 2w
+func LongDuration(register Register, name string, options ...FlagOptionApplyer) *time.Duration {
+	p := new(time.Duration)
+	_ = LongDurationVar(register, p, name, options...)
+	return p
+}
+
+// LongDurationsVar defines a []time.Duration flag with specified name. Unlike
+// DurationsVar, it also understands the "d" (24h) and "w" (7d) units.
+func LongDurationsVar(register Register, p *[]time.Duration, name string, options ...FlagOptionApplyer) error {
+	return Var(register, newLongDurationValues(p), name, options...)
+}
+
+// LongDurations defines a []time.Duration flag with specified name. Unlike
+// Durations, it also understands the "d" (24h) and "w" (7d) units.
+// The return value is the address of a []time.Duration variable that stores
+// values of the flag.
+func LongDurations(register Register, name string, options ...FlagOptionApplyer) *[]time.Duration {
+	p := new([]time.Duration)
+	_ = LongDurationsVar(register, p, name, options...)
+	return p
+}
+
+// LongDurationArgVar defines a time.Duration argument with specified name.
+// Unlike DurationArgVar, it also understands the "d" (24h) and "w" (7d) units.
+func LongDurationArgVar(register Register, p *time.Duration, name string, options ...ArgOptionApplyer) error {
+	return ArgVar(register, newLongDurationValue(p), name, options...)
+}
+
+// LongDurationArg defines a time.Duration argument with specified name.
+// Unlike DurationArg, it also understands the "d" (24h) and "w" (7d) units.
+// The return value is the address of a time.Duration variable that stores the
+// value of the argument.
+func LongDurationArg(register Register, name string, options ...ArgOptionApplyer) *time.Duration {
+	p := new(time.Duration)
+	_ = LongDurationArgVar(register, p, name, options...)
+	return p
+}
+
+// RestLongDurationsVar defines the []time.Duration rest arguments with
+// specified name. Unlike RestDurationsVar, it also understands the "d" (24h)
+// and "w" (7d) units.
+func RestLongDurationsVar(register Register, p *[]time.Duration, name string, options ...RestOptionApplyer) error {
+	return RestVar(register, newLongDurationValues(p), name, options...)
+}
+
+// RestLongDurations defines the []time.Duration rest arguments with specified
+// name. Unlike RestDurations, it also understands the "d" (24h) and "w" (7d)
+// units.
+// The return value is the address of a []time.Duration variable that stores
+// values of arguments.
+func RestLongDurations(register Register, name string, options ...RestOptionApplyer) *[]time.Duration {
+	p := new([]time.Duration)
+	_ = RestLongDurationsVar(register, p, name, options...)
+	return p
+}
+
+// Time.
+
+// TimeFormat configures how Time flags, args and rest args are parsed.
+//
+//   cli.TimeFormat{
+//       Layouts:  []string{"2006-01-02", "2006-01-02 15:04"},
+//       Location: time.UTC,
+//   }
+type TimeFormat struct {
+	Layouts  []string       // Extra layouts, tried after time.RFC3339.
+	Location *time.Location // Used for layouts without a zone and for "now". The default is time.Local.
+}
+
+func (f *TimeFormat) loc() *time.Location {
+	if f.Location != nil {
+		return f.Location
+	}
+
+	return time.Local
+}
+
+// parse parses the time in one of the layouts or the "now", "now-2h" or
+// "now+1d" relative form.
+func (f *TimeFormat) parse(s string) (time.Time, error) {
+	if strings.HasPrefix(s, "now") {
+		now := timeNow().In(f.loc())
+		if s == "now" {
+			return now, nil
+		}
+
+		if s[3] == '-' || s[3] == '+' {
+			d, err := parseDuration(s[3:])
+			if err != nil {
+				return time.Time{}, &ParseValueError{
+					Type: "time.Time",
+					Err:  err,
+				}
+			}
+
+			return now.Add(d), nil
+		}
+	}
+
+	if t, err := time.ParseInLocation(time.RFC3339, s, f.loc()); err == nil {
+		return t, nil
+	}
+
+	for _, layout := range f.Layouts {
+		if t, err := time.ParseInLocation(layout, s, f.loc()); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, &ParseValueError{
+		Type: "time.Time",
+		Err:  ErrSyntax,
+	}
+}
+
+func (f *TimeFormat) format(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+var (
+	_ Value   = (*timeValue)(nil)
+	_ Getter  = (*timeValue)(nil)
+	_ Emptier = (*timeValue)(nil)
+	_ Typer   = (*timeValue)(nil)
+)
+
+type timeValue struct {
+	p      *time.Time
+	format TimeFormat
+}
+
+func newTimeValue(p *time.Time, format TimeFormat) *timeValue {
+	return &timeValue{p: p, format: format}
+}
+
+func (v *timeValue) Set(val string) error {
+	t, err := v.format.parse(val)
+	if err != nil {
+		return err
+	}
+
+	*v.p = t
+	return nil
+}
+
+func (v *timeValue) Get() interface{} { return *v.p }
+
+func (v *timeValue) Empty() bool { return v.p.IsZero() }
+
+func (v *timeValue) String() string { return v.format.format(*v.p) }
+
+func (*timeValue) Type() string { return "time.Time" }
+
+var (
+	_ Value      = (*timeValues)(nil)
+	_ Getter     = (*timeValues)(nil)
+	_ Emptier    = (*timeValues)(nil)
+	_ Typer      = (*timeValues)(nil)
+	_ sliceValue = (*timeValues)(nil)
+)
+
+type timeValues struct {
+	p      *[]time.Time
+	format TimeFormat
+}
+
+func newTimeValues(p *[]time.Time, format TimeFormat) *timeValues {
+	return &timeValues{p: p, format: format}
+}
+
+func (vs *timeValues) Set(val string) error {
+	for _, s := range strings.Split(val, ",") {
+		if s == "" {
+			continue
+		}
+
+		t, err := vs.format.parse(s)
+		if err != nil {
+			return err
+		}
+
+		*vs.p = append(*vs.p, t)
+	}
+
+	return nil
+}
+
+func (vs *timeValues) Get() interface{} { return *vs.p }
+
+func (vs *timeValues) Empty() bool { return len(*vs.p) == 0 }
+
+func (vs *timeValues) String() string {
+	values := make([]string, len(*vs.p))
+	for i, t := range *vs.p {
+		values[i] = vs.format.format(t)
+	}
+
+	return strings.Join(values, ",")
+}
+
+func (*timeValues) Type() string { return "[]time.Time" }
+
+func (*timeValues) IsSliceValue() bool { return true }
+
+// TimeVar defines a time.Time flag with specified name and format. It accepts
+// time.RFC3339, layouts of the format and relative times: "now", "now-2h",
+// "now+1d".
+func TimeVar(register Register, p *time.Time, name string, format TimeFormat, options ...FlagOptionApplyer) error {
+	return Var(register, newTimeValue(p, format), name, options...)
+}
+
+// Time defines a time.Time flag with specified name and format. It accepts
+// time.RFC3339, layouts of the format and relative times: "now", "now-2h",
+// "now+1d".
+// The return value is the address of a time.Time variable that stores the
+// value of the flag.
+//
+//   since := cli.Time(register, "since", cli.TimeFormat{Layouts: []string{"2006-01-02"}, Location: time.UTC})
+func Time(register Register, name string, format TimeFormat, options ...FlagOptionApplyer) *time.Time {
+	p := new(time.Time)
+	_ = TimeVar(register, p, name, format, options...)
+	return p
+}
+
+// TimesVar defines a []time.Time flag with specified name and format.
+func TimesVar(register Register, p *[]time.Time, name string, format TimeFormat, options ...FlagOptionApplyer) error {
+	return Var(register, newTimeValues(p, format), name, options...)
+}
+
+// Times defines a []time.Time flag with specified name and format.
+// The return value is the address of a []time.Time variable that stores values
+// of the flag.
+func Times(register Register, name string, format TimeFormat, options ...FlagOptionApplyer) *[]time.Time {
+	p := new([]time.Time)
+	_ = TimesVar(register, p, name, format, options...)
+	return p
+}
+
+// TimeArgVar defines a time.Time argument with specified name and format.
+func TimeArgVar(register Register, p *time.Time, name string, format TimeFormat, options ...ArgOptionApplyer) error {
+	return ArgVar(register, newTimeValue(p, format), name, options...)
+}
+
+// TimeArg defines a time.Time argument with specified name and format.
+// The return value is the address of a time.Time variable that stores the
+// value of the argument.
+func TimeArg(register Register, name string, format TimeFormat, options ...ArgOptionApplyer) *time.Time {
+	p := new(time.Time)
+	_ = TimeArgVar(register, p, name, format, options...)
+	return p
+}
+
+// RestTimesVar defines the []time.Time rest arguments with specified name and
+// format.
+func RestTimesVar(register Register, p *[]time.Time, name string, format TimeFormat, options ...RestOptionApplyer) error {
+	return RestVar(register, newTimeValues(p, format), name, options...)
+}
+
+// RestTimes defines the []time.Time rest arguments with specified name and
+// format.
+// The return value is the address of a []time.Time variable that stores values
+// of arguments.
+func RestTimes(register Register, name string, format TimeFormat, options ...RestOptionApplyer) *[]time.Time {
+	p := new([]time.Time)
+	_ = RestTimesVar(register, p, name, format, options...)
+	return p
+}
@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tt := []struct {
+		s       string
+		want    time.Duration
+		wantErr error
+	}{
+		{s: "0", want: 0},
+		{s: "90m", want: 90 * time.Minute},
+		{s: "1h30m", want: 90 * time.Minute},
+		{s: "2d", want: 48 * time.Hour},
+		{s: "1w2d12h", want: 9*24*time.Hour + 12*time.Hour},
+		{s: "1.5d", want: 36 * time.Hour},
+		{s: "-2d", want: -48 * time.Hour},
+		{s: "+1w", want: 7 * 24 * time.Hour},
+		{s: "", wantErr: ErrSyntax},
+		{s: "-", wantErr: ErrSyntax},
+		{s: "d", wantErr: ErrSyntax},
+		{s: "2y", wantErr: ErrSyntax},
+		{s: "1h-2m", wantErr: ErrSyntax},
+		{s: "100000w", wantErr: ErrRange},
+		{s: "106751.99116730064d", wantErr: ErrRange},
+		{s: "3000000h", wantErr: ErrRange},
+		{s: "9223372036854775808ns", wantErr: ErrRange},
+		{s: "1w2562047h", wantErr: ErrRange},
+		{s: "1.5.5h", wantErr: ErrSyntax},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.s, func(t *testing.T) {
+			got, err := parseDuration(tc.s)
+			if err != tc.wantErr {
+				t.Fatalf("parseDuration(%q): got error = %v, want error = %v", tc.s, err, tc.wantErr)
+			}
+
+			if got != tc.want {
+				t.Errorf("parseDuration(%q): got = %v, want = %v", tc.s, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParser_Parse_long_duration(t *testing.T) {
+	var (
+		register DefaultRegister
+		parser   DefaultParser
+	)
+
+	offset := LongDuration(&register, "offset", WithShort("o"))
+	retention := LongDurationArg(&register, "retention")
+	steps := RestLongDurations(&register, "steps")
+
+	err := parser.Parse(nil, &register, []string{"-o", "-2d", "1w", "-1d", "12h"})
+	if err != nil {
+		t.Fatalf("Parse(): failed to parse args: %s", err)
+	}
+
+	if want := -48 * time.Hour; *offset != want {
+		t.Errorf("Parse(): offset: got = %v, want = %v", *offset, want)
+	}
+
+	if want := 7 * 24 * time.Hour; *retention != want {
+		t.Errorf("Parse(): retention: got = %v, want = %v", *retention, want)
+	}
+
+	if want := []time.Duration{-24 * time.Hour, 12 * time.Hour}; !reflect.DeepEqual(*steps, want) {
+		t.Errorf("Parse(): steps: got = %v, want = %v", *steps, want)
+	}
+}
+
+func TestParser_Parse_time(t *testing.T) {
+	now := time.Date(2021, 3, 14, 15, 9, 26, 0, time.UTC)
+
+	defer func(fn func() time.Time) { timeNow = fn }(timeNow)
+	timeNow = func() time.Time { return now }
+
+	tt := []struct {
+		name    string
+		args    []string
+		want    time.Time
+		wantErr error
+	}{
+		{
+			name: "rfc3339",
+			args: []string{"--since", "2021-01-02T03:04:05Z"},
+			want: time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		{
+			name: "layout in zone",
+			args: []string{"--since", "2021-01-02 03:04"},
+			want: time.Date(2021, 1, 2, 3, 4, 0, 0, time.UTC),
+		},
+		{
+			name: "date",
+			args: []string{"--since", "2021-01-02"},
+			want: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "now",
+			args: []string{"--since", "now"},
+			want: now,
+		},
+		{
+			name: "now minus",
+			args: []string{"--since", "now-2h"},
+			want: now.Add(-2 * time.Hour),
+		},
+		{
+			name: "now plus",
+			args: []string{"--since", "now+1d"},
+			want: now.Add(24 * time.Hour),
+		},
+		{
+			name:    "invalid",
+			args:    []string{"--since", "yesterday"},
+			wantErr: &FlagError{Long: "since", Err: &ParseValueError{Type: "time.Time", Err: ErrSyntax}},
+		},
+		{
+			name:    "invalid relative",
+			args:    []string{"--since", "now-2x"},
+			wantErr: &FlagError{Long: "since", Err: &ParseValueError{Type: "time.Time", Err: ErrSyntax}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			since := Time(&register, "since", TimeFormat{
+				Layouts:  []string{"2006-01-02", "2006-01-02 15:04"},
+				Location: time.UTC,
+			})
+
+			err := parser.Parse(nil, &register, tc.args)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("Parse(): got error = %q, want error = %q", err, tc.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Parse(): failed to parse args: %s", err)
+			}
+
+			if !since.Equal(tc.want) {
+				t.Errorf("Parse(): since: got = %v, want = %v", *since, tc.want)
+			}
+
+			if since.Location() != time.UTC {
+				t.Errorf("Parse(): since: got location = %v, want location = %v", since.Location(), time.UTC)
+			}
+		})
+	}
+}
+
+func TestParser_Parse_time_args(t *testing.T) {
+	var (
+		register DefaultRegister
+		parser   DefaultParser
+	)
+
+	from := TimeArg(&register, "from", TimeFormat{Location: time.UTC})
+	rest := RestTimes(&register, "times", TimeFormat{Layouts: []string{"2006-01-02"}, Location: time.UTC})
+
+	err := parser.Parse(nil, &register, []string{"2021-01-02T03:04:05Z", "2021-01-03", "2021-01-04"})
+	if err != nil {
+		t.Fatalf("Parse(): failed to parse args: %s", err)
+	}
+
+	if want := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC); !from.Equal(want) {
+		t.Errorf("Parse(): from: got = %v, want = %v", *from, want)
+	}
+
+	want := []time.Time{
+		time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(*rest, want) {
+		t.Errorf("Parse(): times: got = %v, want = %v", *rest, want)
+	}
+}
+
+func TestDefaultHelper_Help_time(t *testing.T) {
+	app := App{
+		Name: "time",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Time(cmd, "since", TimeFormat{})
+			_ = LongDuration(cmd, "retention")
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+	}
+
+	cmd, err := app.Command("time")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := (DefaultHelper{}).Help(cmd, &buf); err != nil {
+		t.Fatalf("Help(): failed to write help: %s", err)
+	}
+
+	assertContains(t, buf.String(),
+		"--since time.Time",
+		"--retention time.Duration",
+	)
+}
@@ -0,0 +1,580 @@
+package cli
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var ErrUnknownUnit = errors.New("unknown unit")
+
+// UnitItem is a suffix of a number and its multiplier.
+type UnitItem struct {
+	Suffix     string
+	Multiplier float64
+}
+
+// Units is a list of suffixes of numbers. A number without a suffix is
+// multiplied by 1.
+//
+//   cli.Units{
+//     {Suffix: "k", Multiplier: 1e3},
+//     {Suffix: "M", Multiplier: 1e6},
+//   }
+type Units []UnitItem
+
+// Parse parses a number with an optional suffix, e.g. "1.5k".
+func (u Units) Parse(s string) (float64, error) {
+	num, suffix := splitNumber(s)
+
+	// The number is checked before the unit: "abc" is not a number.
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		if ne, ok := err.(*strconv.NumError); ok && ne.Err == strconv.ErrRange {
+			return 0, ErrRange
+		}
+
+		return 0, ErrSyntax
+	}
+
+	// Only finite numbers: "NaN" and "Inf" are not numbers.
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, ErrSyntax
+	}
+
+	mult := 1.0
+	if suffix != "" {
+		item, ok := u.lookup(suffix)
+		if !ok {
+			return 0, ErrUnknownUnit
+		}
+
+		mult = item.Multiplier
+	}
+
+	if math.IsInf(v*mult, 0) {
+		return 0, ErrRange
+	}
+
+	return v * mult, nil
+}
+
+// Format formats the number with the shortest unit which keeps the value
+// exact: 1500 becomes "1.5k". Units win ties with the plain number.
+func (u Units) Format(v float64) string {
+	plain := formatNumber(v)
+
+	best := plain
+	for _, item := range u {
+		if item.Multiplier <= 1 || math.Abs(v) < item.Multiplier {
+			continue
+		}
+
+		s := formatNumber(v/item.Multiplier) + item.Suffix
+		if len(s) < len(best) || (len(s) == len(best) && best == plain) {
+			if n, err := u.Parse(s); err == nil && n == v {
+				best = s
+			}
+		}
+	}
+
+	return best
+}
+
+func (u Units) lookup(suffix string) (UnitItem, bool) {
+	for _, item := range u {
+		if item.Suffix == suffix {
+			return item, true
+		}
+	}
+
+	return UnitItem{}, false
+}
+
+// splitNumber splits a string into a number and a suffix: "1.5GiB" becomes
+// "1.5" and "GiB".
+func splitNumber(s string) (num, suffix string) {
+	i := len(s)
+	for i > 0 {
+		c := s[i-1]
+		if ('0' <= c && c <= '9') || c == '.' {
+			break
+		}
+
+		i--
+	}
+
+	return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i:])
+}
+
+// Number.
+
+var (
+	_ Value   = (*numberValue)(nil)
+	_ Getter  = (*numberValue)(nil)
+	_ Emptier = (*numberValue)(nil)
+	_ Typer   = (*numberValue)(nil)
+)
+
+type numberValue struct {
+	p     *float64
+	units Units
+}
+
+func newNumberValue(p *float64, units Units) *numberValue {
+	return &numberValue{p: p, units: units}
+}
+
+func (v *numberValue) Set(val string) error {
+	n, err := v.units.Parse(val)
+	if err != nil {
+		return &ParseValueError{
+			Type: "number",
+			Err:  err,
+		}
+	}
+
+	*v.p = n
+	return nil
+}
+
+func (v *numberValue) Get() interface{} { return *v.p }
+
+func (v *numberValue) Empty() bool { return *v.p == 0 }
+
+func (v *numberValue) String() string { return v.units.Format(*v.p) }
+
+func (*numberValue) Type() string { return "number" }
+
+var (
+	_ Value      = (*numberValues)(nil)
+	_ Getter     = (*numberValues)(nil)
+	_ Emptier    = (*numberValues)(nil)
+	_ Typer      = (*numberValues)(nil)
+	_ sliceValue = (*numberValues)(nil)
+)
+
+type numberValues struct {
+	p     *[]float64
+	units Units
+}
+
+func newNumberValues(p *[]float64, units Units) *numberValues {
+	return &numberValues{p: p, units: units}
+}
+
+func (vs *numberValues) Set(val string) error {
+	for _, s := range strings.Split(val, ",") {
+		if s == "" {
+			continue
+		}
+
+		var n float64
+		if err := newNumberValue(&n, vs.units).Set(s); err != nil {
+			return err
+		}
+
+		*vs.p = append(*vs.p, n)
+	}
+
+	return nil
+}
+
+func (vs *numberValues) Get() interface{} { return *vs.p }
+
+func (vs *numberValues) Empty() bool { return len(*vs.p) == 0 }
+
+func (vs *numberValues) String() string {
+	values := make([]string, len(*vs.p))
+	for i, n := range *vs.p {
+		values[i] = vs.units.Format(n)
+	}
+
+	return strings.Join(values, ",")
+}
+
+func (*numberValues) Type() string { return "[]number" }
+
+func (*numberValues) IsSliceValue() bool { return true }
+
+// NumberVar defines a float64 flag with specified name and unit suffixes.
+func NumberVar(register Register, p *float64, name string, units Units, options ...FlagOptionApplyer) error {
+	return Var(register, newNumberValue(p, units), name, options...)
+}
+
+// Number defines a float64 flag with specified name and unit suffixes.
+// The return value is the address of a float64 variable that stores the value
+// of the flag.
+//
+//   rate := cli.Number(register, "rate", cli.Units{{Suffix: "k", Multiplier: 1e3}})
+func Number(register Register, name string, units Units, options ...FlagOptionApplyer) *float64 {
+	p := new(float64)
+	_ = NumberVar(register, p, name, units, options...)
+	return p
+}
+
+// NumberArgVar defines a float64 argument with specified name and unit
+// suffixes.
+func NumberArgVar(register Register, p *float64, name string, units Units, options ...ArgOptionApplyer) error {
+	return ArgVar(register, newNumberValue(p, units), name, options...)
+}
+
+// NumberArg defines a float64 argument with specified name and unit suffixes.
+// The return value is the address of a float64 variable that stores the value
+// of the argument.
+func NumberArg(register Register, name string, units Units, options ...ArgOptionApplyer) *float64 {
+	p := new(float64)
+	_ = NumberArgVar(register, p, name, units, options...)
+	return p
+}
+
+// RestNumbersVar defines the []float64 rest arguments with specified name and
+// unit suffixes.
+func RestNumbersVar(register Register, p *[]float64, name string, units Units, options ...RestOptionApplyer) error {
+	return RestVar(register, newNumberValues(p, units), name, options...)
+}
+
+// RestNumbers defines the []float64 rest arguments with specified name and
+// unit suffixes.
+// The return value is the address of a []float64 variable that stores values
+// of arguments.
+func RestNumbers(register Register, name string, units Units, options ...RestOptionApplyer) *[]float64 {
+	p := new([]float64)
+	_ = RestNumbersVar(register, p, name, units, options...)
+	return p
+}
+
+// Size.
+
+// sizeUnits are SI and IEC units of byte sizes. Suffixes are case-insensitive
+// and the "B" is optional: "10MB", "10m", "1.5GiB", "512k".
+var sizeUnits = []struct {
+	suffix string
+	mult   uint64
+}{
+	{"EiB", 1 << 60},
+	{"PiB", 1 << 50},
+	{"TiB", 1 << 40},
+	{"GiB", 1 << 30},
+	{"MiB", 1 << 20},
+	{"KiB", 1 << 10},
+	{"EB", 1e18},
+	{"PB", 1e15},
+	{"TB", 1e12},
+	{"GB", 1e9},
+	{"MB", 1e6},
+	{"KB", 1e3},
+	{"B", 1},
+}
+
+// parseSize parses a byte size with an SI or IEC unit.
+func parseSize(s string) (uint64, error) {
+	num, suffix := splitNumber(s)
+
+	// Sizes are plain decimal numbers. The number is checked before the unit.
+	if !isDecimal(num) {
+		return 0, ErrSyntax
+	}
+
+	r, ok := new(big.Rat).SetString(num)
+	if !ok {
+		return 0, ErrSyntax
+	}
+
+	mult := uint64(1)
+	if suffix != "" {
+		found := false
+		for _, u := range sizeUnits {
+			if strings.EqualFold(suffix, u.suffix) || strings.EqualFold(suffix, strings.TrimSuffix(u.suffix, "B")) {
+				mult, found = u.mult, true
+				break
+			}
+		}
+
+		if !found {
+			return 0, ErrUnknownUnit
+		}
+	}
+
+	r.Mul(r, new(big.Rat).SetInt(new(big.Int).SetUint64(mult)))
+	if !r.IsInt() {
+		return 0, ErrSyntax
+	}
+
+	n := r.Num()
+	if !n.IsUint64() {
+		return 0, ErrRange
+	}
+
+	return n.Uint64(), nil
+}
+
+// isDecimal reports whether s is a non-negative decimal number: digits with an
+// optional fraction ("10", "1.5").
+func isDecimal(s string) bool {
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i != -1 {
+		intPart, frac = s[:i], s[i+1:]
+		if frac == "" {
+			return false
+		}
+	}
+
+	if intPart == "" {
+		return false
+	}
+
+	for _, part := range []string{intPart, frac} {
+		for i := 0; i < len(part); i++ {
+			if part[i] < '0' || part[i] > '9' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// formatSize formats a byte size with the shortest unit which divides it
+// evenly: 67108864 becomes "64MiB".
+func formatSize(n uint64) string {
+	best := strconv.FormatUint(n, 10) + "B"
+	if n == 0 {
+		return best
+	}
+
+	for _, u := range sizeUnits {
+		if n%u.mult != 0 {
+			continue
+		}
+
+		s := strconv.FormatUint(n/u.mult, 10) + u.suffix
+		if len(s) < len(best) {
+			best = s
+		}
+	}
+
+	return best
+}
+
+var (
+	_ Value   = (*sizeValue)(nil)
+	_ Getter  = (*sizeValue)(nil)
+	_ Emptier = (*sizeValue)(nil)
+	_ Typer   = (*sizeValue)(nil)
+)
+
+type sizeValue uint64
+
+func newSizeValue(p *uint64) *sizeValue {
+	return (*sizeValue)(p)
+}
+
+func (s *sizeValue) Set(val string) error {
+	v, err := parseSize(val)
+	if err != nil {
+		return &ParseValueError{
+			Type: "size",
+			Err:  err,
+		}
+	}
+
+	*s = sizeValue(v)
+	return nil
+}
+
+func (s *sizeValue) Get() interface{} { return uint64(*s) }
+
+func (s *sizeValue) Empty() bool { return *s == 0 }
+
+func (s *sizeValue) String() string { return formatSize(uint64(*s)) }
+
+func (*sizeValue) Type() string { return "size" }
+
+var (
+	_ Value      = (*sizeValues)(nil)
+	_ Getter     = (*sizeValues)(nil)
+	_ Emptier    = (*sizeValues)(nil)
+	_ Typer      = (*sizeValues)(nil)
+	_ sliceValue = (*sizeValues)(nil)
+)
+
+type sizeValues []uint64
+
+func newSizeValues(p *[]uint64) *sizeValues {
+	return (*sizeValues)(p)
+}
+
+func (vs *sizeValues) Set(val string) error {
+	for _, s := range strings.Split(val, ",") {
+		if s == "" {
+			continue
+		}
+
+		var v uint64
+		if err := newSizeValue(&v).Set(s); err != nil {
+			return err
+		}
+
+		*vs = append(*vs, v)
+	}
+
+	return nil
+}
+
+func (vs *sizeValues) Get() interface{} { return []uint64(*vs) }
+
+func (vs *sizeValues) Empty() bool { return len(*vs) == 0 }
+
+func (vs *sizeValues) String() string {
+	values := make([]string, len(*vs))
+	for i, v := range *vs {
+		values[i] = formatSize(v)
+	}
+
+	return strings.Join(values, ",")
+}
+
+func (*sizeValues) Type() string { return "[]size" }
+
+func (*sizeValues) IsSliceValue() bool { return true }
+
+// SizeVar defines a byte size flag with specified name. It accepts SI and IEC
+// units: "10MB", "1.5GiB", "512k".
+func SizeVar(register Register, p *uint64, name string, options ...FlagOptionApplyer) error {
+	return Var(register, newSizeValue(p), name, options...)
+}
+
+// Size defines a byte size flag with specified name. It accepts SI and IEC
+// units: "10MB", "1.5GiB", "512k".
+// The return value is the address of a uint64 variable that stores the value
+// of the flag in bytes.
+//
+//   cacheSize := cli.Size(register, "cache-size")
+func Size(register Register, name string, options ...FlagOptionApplyer) *uint64 {
+	p := new(uint64)
+	_ = SizeVar(register, p, name, options...)
+	return p
+}
+
+// SizesVar defines a []byte size flag with specified name.
+func SizesVar(register Register, p *[]uint64, name string, options ...FlagOptionApplyer) error {
+	return Var(register, newSizeValues(p), name, options...)
+}
+
+// Sizes defines a []byte size flag with specified name.
+// The return value is the address of a []uint64 variable that stores values
+// of the flag in bytes.
+func Sizes(register Register, name string, options ...FlagOptionApplyer) *[]uint64 {
+	p := new([]uint64)
+	_ = SizesVar(register, p, name, options...)
+	return p
+}
+
+// SizeArgVar defines a byte size argument with specified name.
+func SizeArgVar(register Register, p *uint64, name string, options ...ArgOptionApplyer) error {
+	return ArgVar(register, newSizeValue(p), name, options...)
+}
+
+// SizeArg defines a byte size argument with specified name.
+// The return value is the address of a uint64 variable that stores the value
+// of the argument in bytes.
+func SizeArg(register Register, name string, options ...ArgOptionApplyer) *uint64 {
+	p := new(uint64)
+	_ = SizeArgVar(register, p, name, options...)
+	return p
+}
+
+// RestSizesVar defines the []byte size rest arguments with specified name.
+func RestSizesVar(register Register, p *[]uint64, name string, options ...RestOptionApplyer) error {
+	return RestVar(register, newSizeValues(p), name, options...)
+}
+
+// RestSizes defines the []byte size rest arguments with specified name.
+// The return value is the address of a []uint64 variable that stores values
+// of arguments in bytes.
+func RestSizes(register Register, name string, options ...RestOptionApplyer) *[]uint64 {
+	p := new([]uint64)
+	_ = RestSizesVar(register, p, name, options...)
+	return p
+}
+
+// Percent.
+
+var (
+	_ Value   = (*percentValue)(nil)
+	_ Getter  = (*percentValue)(nil)
+	_ Emptier = (*percentValue)(nil)
+	_ Typer   = (*percentValue)(nil)
+)
+
+// percentValue stores a percentage as a fraction: "50%" becomes 0.5.
+type percentValue float64
+
+func newPercentValue(p *float64) *percentValue {
+	return (*percentValue)(p)
+}
+
+func (pv *percentValue) Set(s string) error {
+	v, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(s, "%")), 64)
+	if err != nil {
+		return numError("percent", err)
+	}
+
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return &ParseValueError{
+			Type: "percent",
+			Err:  ErrSyntax,
+		}
+	}
+
+	*pv = percentValue(v / 100)
+	return nil
+}
+
+func (pv *percentValue) Get() interface{} { return float64(*pv) }
+
+func (pv *percentValue) Empty() bool { return *pv == 0 }
+
+func (pv *percentValue) String() string {
+	// Hide float errors: 0.07 * 100 is 7.000000000000001.
+	return strconv.FormatFloat(float64(*pv)*100, 'g', 12, 64) + "%"
+}
+
+func (*percentValue) Type() string { return "percent" }
+
+// PercentVar defines a percentage flag with specified name. The value is
+// stored as a fraction: "50%" (or "50") becomes 0.5.
+func PercentVar(register Register, p *float64, name string, options ...FlagOptionApplyer) error {
+	return Var(register, newPercentValue(p), name, options...)
+}
+
+// Percent defines a percentage flag with specified name. The value is stored
+// as a fraction: "50%" (or "50") becomes 0.5.
+// The return value is the address of a float64 variable that stores the value
+// of the flag.
+//
+//   threshold := cli.Percent(register, "threshold")
+func Percent(register Register, name string, options ...FlagOptionApplyer) *float64 {
+	p := new(float64)
+	_ = PercentVar(register, p, name, options...)
+	return p
+}
+
+// PercentArgVar defines a percentage argument with specified name. The value
+// is stored as a fraction: "50%" (or "50") becomes 0.5.
+func PercentArgVar(register Register, p *float64, name string, options ...ArgOptionApplyer) error {
+	return ArgVar(register, newPercentValue(p), name, options...)
+}
+
+// PercentArg defines a percentage argument with specified name. The value is
+// stored as a fraction: "50%" (or "50") becomes 0.5.
+// The return value is the address of a float64 variable that stores the value
+// of the argument.
+func PercentArg(register Register, name string, options ...ArgOptionApplyer) *float64 {
+	p := new(float64)
+	_ = PercentArgVar(register, p, name, options...)
+	return p
+}
@@ -0,0 +1,244 @@
+package cli
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSize(t *testing.T) {
+	tt := []struct {
+		s       string
+		want    uint64
+		wantErr error
+	}{
+		{s: "0", want: 0},
+		{s: "512", want: 512},
+		{s: "512B", want: 512},
+		{s: "512k", want: 512000},
+		{s: "10MB", want: 10000000},
+		{s: "10mb", want: 10000000},
+		{s: "1.5GiB", want: 1610612736},
+		{s: "64Mi", want: 64 << 20},
+		{s: "1 KiB", want: 1024},
+		{s: "0.5KB", want: 500},
+		{s: "16EiB", wantErr: ErrRange},
+		{s: "0.5B", wantErr: ErrSyntax},
+		{s: "MB", wantErr: ErrSyntax},
+		{s: "1.2.3MB", wantErr: ErrSyntax},
+		{s: "10XB", wantErr: ErrUnknownUnit},
+		{s: "abc", wantErr: ErrSyntax},
+		{s: "-5MB", wantErr: ErrSyntax},
+		{s: "1/2MB", wantErr: ErrSyntax},
+		{s: "0x10KiB", wantErr: ErrSyntax},
+		{s: "1e3", wantErr: ErrSyntax},
+		{s: "+5MB", wantErr: ErrSyntax},
+		{s: ".5KB", wantErr: ErrSyntax},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.s, func(t *testing.T) {
+			got, err := parseSize(tc.s)
+			if err != tc.wantErr {
+				t.Fatalf("parseSize(%q): got error = %v, want error = %v", tc.s, err, tc.wantErr)
+			}
+
+			if got != tc.want {
+				t.Errorf("parseSize(%q): got = %v, want = %v", tc.s, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tt := []struct {
+		n    uint64
+		want string
+	}{
+		{0, "0B"},
+		{512, "512B"},
+		{1000, "1KB"},
+		{1024, "1KiB"},
+		{1536, "1536B"},
+		{64 << 20, "64MiB"},
+		{10000000, "10MB"},
+	}
+
+	for _, tc := range tt {
+		if got := formatSize(tc.n); got != tc.want {
+			t.Errorf("formatSize(%d): got = %q, want = %q", tc.n, got, tc.want)
+		}
+	}
+}
+
+func TestUnits(t *testing.T) {
+	units := Units{
+		{Suffix: "k", Multiplier: 1e3},
+		{Suffix: "M", Multiplier: 1e6},
+	}
+
+	tt := []struct {
+		s       string
+		want    float64
+		wantErr error
+		format  string
+	}{
+		{s: "12", want: 12, format: "12"},
+		{s: "1.5k", want: 1500, format: "1.5k"},
+		{s: "2M", want: 2e6, format: "2M"},
+		{s: "2000k", want: 2e6, format: "2M"},
+		{s: "2m", wantErr: ErrUnknownUnit},
+		{s: "k", wantErr: ErrSyntax},
+		{s: "abc", wantErr: ErrSyntax},
+		{s: "NaN", wantErr: ErrSyntax},
+		{s: "+Inf", wantErr: ErrSyntax},
+		{s: "1e308M", wantErr: ErrRange},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.s, func(t *testing.T) {
+			got, err := units.Parse(tc.s)
+			if err != tc.wantErr {
+				t.Fatalf("Parse(%q): got error = %v, want error = %v", tc.s, err, tc.wantErr)
+			}
+
+			if got != tc.want {
+				t.Errorf("Parse(%q): got = %v, want = %v", tc.s, got, tc.want)
+			}
+
+			if tc.wantErr == nil {
+				if s := units.Format(got); s != tc.format {
+					t.Errorf("Format(%v): got = %q, want = %q", got, s, tc.format)
+				}
+			}
+		})
+	}
+}
+
+func TestParser_Parse_units(t *testing.T) {
+	var (
+		register DefaultRegister
+		parser   DefaultParser
+	)
+
+	cache := Size(&register, "cache-size")
+	threshold := Percent(&register, "threshold")
+	rate := Number(&register, "rate", Units{{Suffix: "k", Multiplier: 1e3}})
+	limit := SizeArg(&register, "limit")
+	sizes := RestSizes(&register, "sizes")
+
+	err := parser.Parse(nil, &register, []string{
+		"--cache-size", "64MiB",
+		"--threshold", "7.5%",
+		"--rate", "2.5k",
+		"1GB",
+		"1k", "2KiB",
+	})
+	if err != nil {
+		t.Fatalf("Parse(): failed to parse args: %s", err)
+	}
+
+	if want := uint64(64 << 20); *cache != want {
+		t.Errorf("Parse(): cache-size: got = %v, want = %v", *cache, want)
+	}
+
+	if want := 0.075; *threshold != want {
+		t.Errorf("Parse(): threshold: got = %v, want = %v", *threshold, want)
+	}
+
+	if want := 2500.0; *rate != want {
+		t.Errorf("Parse(): rate: got = %v, want = %v", *rate, want)
+	}
+
+	if want := uint64(1e9); *limit != want {
+		t.Errorf("Parse(): limit: got = %v, want = %v", *limit, want)
+	}
+
+	if want := []uint64{1000, 2048}; !reflect.DeepEqual(*sizes, want) {
+		t.Errorf("Parse(): sizes: got = %v, want = %v", *sizes, want)
+	}
+}
+
+func TestParser_Parse_units_errors(t *testing.T) {
+	tt := []struct {
+		name string
+		args []string
+		want error
+	}{
+		{
+			name: "size",
+			args: []string{"--cache-size", "64MX"},
+			want: &FlagError{Long: "cache-size", Err: &ParseValueError{Type: "size", Err: ErrUnknownUnit}},
+		},
+		{
+			name: "percent",
+			args: []string{"--threshold", "half"},
+			want: &FlagError{Long: "threshold", Err: &ParseValueError{Type: "percent", Err: ErrSyntax}},
+		},
+		{
+			name: "percent nan",
+			args: []string{"--threshold=NaN%"},
+			want: &FlagError{Long: "threshold", Err: &ParseValueError{Type: "percent", Err: ErrSyntax}},
+		},
+		{
+			name: "percent inf",
+			args: []string{"--threshold=+Inf"},
+			want: &FlagError{Long: "threshold", Err: &ParseValueError{Type: "percent", Err: ErrSyntax}},
+		},
+		{
+			name: "number",
+			args: []string{"--rate", "2.5x"},
+			want: &FlagError{Long: "rate", Err: &ParseValueError{Type: "number", Err: ErrUnknownUnit}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			_ = Size(&register, "cache-size")
+			_ = Percent(&register, "threshold")
+			_ = Number(&register, "rate", Units{{Suffix: "k", Multiplier: 1e3}})
+
+			if err := parser.Parse(nil, &register, tc.args); !errors.Is(err, tc.want) {
+				t.Errorf("Parse(): got error = %q, want error = %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestDefaultHelper_Help_units(t *testing.T) {
+	app := App{
+		Name: "units",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			cacheSize := uint64(64 << 20)
+			_ = SizeVar(cmd, &cacheSize, "cache-size", Usage("Cache size"))
+
+			threshold := 0.07
+			_ = PercentVar(cmd, &threshold, "threshold", Usage("Threshold"))
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+	}
+
+	cmd, err := app.Command("units")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := (DefaultHelper{}).Help(cmd, &buf); err != nil {
+		t.Fatalf("Help(): failed to write help: %s", err)
+	}
+
+	assertContains(t, buf.String(),
+		"--cache-size size",
+		"Cache size (default: 64MiB)",
+		"--threshold percent",
+		"Threshold (default: 7%)",
+	)
+}
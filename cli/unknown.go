@@ -0,0 +1,62 @@
+package cli
+
+// UnknownRegister is an optional interface of a Register. The parser keeps
+// ignored flags and args in it (see DefaultParser.IgnoreUnknownFlags and
+// DefaultParser.IgnoreUnknownArgs) in the order of the command line.
+type UnknownRegister interface {
+	AddUnknown(args ...string)
+	Unknown() []string
+}
+
+func addUnknown(r Register, args ...string) {
+	if ur, ok := r.(UnknownRegister); ok {
+		ur.AddUnknown(args...)
+	}
+}
+
+func (r *DefaultRegister) AddUnknown(args ...string) {
+	r.unknown = append(r.unknown, args...)
+}
+
+func (r *DefaultRegister) Unknown() []string {
+	return r.unknown
+}
+
+// Unknown returns ignored flags and args of the command chain in the order of
+// the command line. Only inline values are kept in the flag token:
+//
+//   --var-file=dev.tfvars -> ["--var-file=dev.tfvars"]
+//   --var-file dev.tfvars -> ["--var-file", "dev.tfvars"] (if the value is ignored too)
+//
+// It can be used to forward them to another program.
+func (c *Command) Unknown() []string {
+	var unknown []string
+	if c.parent != nil {
+		unknown = append(unknown, c.parent.Unknown()...)
+	}
+
+	if ur, ok := c.register.(UnknownRegister); ok {
+		unknown = append(unknown, ur.Unknown()...)
+	}
+
+	return unknown
+}
+
+// unknownFlagValue reports whether the next argument is a value of an unknown
+// flag (see DefaultParser.UnknownFlagValues). It is if it isn't a flag or a
+// command and no args can take it.
+func (p *DefaultParser) unknownFlagValue(commander Commander, r Register, argMode bool, argIdx int, next string) bool {
+	if len(next) > 0 && next[0] == '-' && next != "-" && !isNumber(next) && !isDuration(next) {
+		return false
+	}
+
+	if !argMode && commander != nil && commander.IsCommand(next) {
+		return false
+	}
+
+	if _, ok := r.Arg(argIdx); ok {
+		return false
+	}
+
+	return r.Rest() == nil
+}
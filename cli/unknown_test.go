@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParser_Parse_unknown(t *testing.T) {
+	tt := []struct {
+		name        string
+		parser      DefaultParser
+		args        []string
+		withArg     bool
+		wantUnknown []string
+		wantVerbose bool
+		wantArg     string
+	}{
+		{
+			name:        "inline values",
+			parser:      DefaultParser{IgnoreUnknownFlags: true},
+			args:        []string{"--var-file=dev.tfvars", "-v", "-x=1"},
+			wantUnknown: []string{"--var-file=dev.tfvars", "-x=1"},
+			wantVerbose: true,
+		},
+		{
+			name:        "next values",
+			parser:      DefaultParser{IgnoreUnknownFlags: true, UnknownFlagValues: true},
+			args:        []string{"--var-file", "dev.tfvars", "-v", "-x", "1", "--force", "--name", "-"},
+			wantUnknown: []string{"--var-file", "dev.tfvars", "-x", "1", "--force", "--name", "-"},
+			wantVerbose: true,
+		},
+		{
+			name:        "next values go to args",
+			parser:      DefaultParser{IgnoreUnknownFlags: true},
+			args:        []string{"--force", "target", "-v"},
+			withArg:     true,
+			wantUnknown: []string{"--force"},
+			wantVerbose: true,
+			wantArg:     "target",
+		},
+		{
+			name:        "args take values",
+			parser:      DefaultParser{IgnoreUnknownFlags: true, UnknownFlagValues: true},
+			args:        []string{"--force", "target", "--name", "app"},
+			withArg:     true,
+			wantUnknown: []string{"--force", "--name", "app"},
+			wantArg:     "target",
+		},
+		{
+			name:        "combined short flags",
+			parser:      DefaultParser{IgnoreUnknownFlags: true},
+			args:        []string{"-xvy", "-zv"},
+			wantUnknown: []string{"-x", "-y", "-z"},
+			wantVerbose: true,
+		},
+		{
+			name:        "disabled posix style",
+			parser:      DefaultParser{IgnoreUnknownFlags: true, DisablePosixStyle: true},
+			args:        []string{"-xv", "-v"},
+			wantUnknown: []string{"-xv"},
+			wantVerbose: true,
+		},
+		{
+			name:        "unknown args",
+			parser:      DefaultParser{IgnoreUnknownFlags: true, IgnoreUnknownArgs: true},
+			args:        []string{"target", "plan", "--out", "plan.out", "--", "-v"},
+			withArg:     true,
+			wantUnknown: []string{"plan", "--out", "plan.out", "-v"},
+			wantArg:     "target",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				arg      string
+			)
+
+			verbose := Bool(&register, "verbose", WithShort("v"))
+			if tc.withArg {
+				_ = StringArgVar(&register, &arg, "arg", Optional)
+			}
+
+			if err := tc.parser.Parse(nil, &register, tc.args); err != nil {
+				t.Fatalf("Parse(): failed to parse args: %s", err)
+			}
+
+			if got := register.Unknown(); !reflect.DeepEqual(got, tc.wantUnknown) {
+				t.Errorf("Parse(): unknown: got = %#v, want = %#v", got, tc.wantUnknown)
+			}
+
+			if *verbose != tc.wantVerbose {
+				t.Errorf("Parse(): verbose: got = %v, want = %v", *verbose, tc.wantVerbose)
+			}
+
+			if arg != tc.wantArg {
+				t.Errorf("Parse(): arg: got = %q, want = %q", arg, tc.wantArg)
+			}
+		})
+	}
+}
+
+func TestApp_Run_unknown(t *testing.T) {
+	var rootUnknown, subUnknown []string
+
+	app := App{
+		Name: "test",
+		Args: []string{"--root", "sub", "--chdir=infra", "apply", "--auto-approve"},
+		Parser: &DefaultParser{
+			IgnoreUnknownFlags: true,
+			IgnoreUnknownArgs:  true,
+		},
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			return func(cmd *Command) error {
+				rootUnknown = cmd.Unknown()
+				return nil
+			}
+		}),
+		Commands: []Command{
+			{
+				Name: "sub",
+				Action: ActionFunc(func(cmd *Command) ActionRunner {
+					return func(cmd *Command) error {
+						rootUnknown = cmd.parent.Unknown()
+						subUnknown = cmd.Unknown()
+						return nil
+					}
+				}),
+			},
+		},
+	}
+
+	if err := app.Run(); err != nil {
+		t.Fatalf("Run(): failed to run: %s", err)
+	}
+
+	wantRoot := []string{"--root"}
+	if !reflect.DeepEqual(rootUnknown, wantRoot) {
+		t.Errorf("Run(): root unknown: got = %#v, want = %#v", rootUnknown, wantRoot)
+	}
+
+	wantSub := []string{"--root", "--chdir=infra", "apply", "--auto-approve"}
+	if !reflect.DeepEqual(subUnknown, wantSub) {
+		t.Errorf("Run(): sub unknown: got = %#v, want = %#v", subUnknown, wantSub)
+	}
+}
@@ -0,0 +1,286 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+)
+
+var (
+	ErrEmpty = errors.New("empty value")
+
+	ErrTooShort = errors.New("too short")
+
+	ErrMismatch = errors.New("does not match")
+
+	ErrNotNumber = errors.New("not a number")
+)
+
+var (
+	_ FlagOptionApplyer = Validator{}
+	_ ArgOptionApplyer  = Validator{}
+	_ RestOptionApplyer = Validator{}
+)
+
+// Validator checks a value of a flag, an arg or every value of rest args after
+// it has been set. Errors are reported as a ParseValueError.
+type Validator struct {
+	Func        func(v interface{}) error
+	Description string // Shown in the help, e.g. "1..64".
+
+	// check reports at the registration whether the validator supports the
+	// value (e.g. Range supports only numbers).
+	check func(v interface{}) error
+}
+
+func (v Validator) FlagOptionApply(o *FlagOptions) {
+	o.Validators = append(o.Validators, v)
+}
+
+func (v Validator) ArgOptionApply(o *ArgOptions) {
+	o.Validators = append(o.Validators, v)
+}
+
+func (v Validator) RestOptionApply(o *RestOptions) {
+	o.Validators = append(o.Validators, v)
+}
+
+// Validate checks the value with the function. The function gets the value
+// returned by the Getter (e.g. int for Int flags).
+//
+//   _ = cli.Int(register, "port", cli.Validate(func(v interface{}) error {
+//     if v.(int) == 0 {
+//       return errors.New("random ports are not allowed")
+//     }
+//
+//     return nil
+//   }))
+func Validate(fn func(v interface{}) error) Validator {
+	return Validator{Func: fn}
+}
+
+// Range allows only numbers in the [min, max] range. It can be used only with
+// numeric values; other values and invalid bounds (NaN or min > max) are
+// reported at the registration.
+//
+//   _ = cli.Uint8(register, "jobs", cli.Range(1, 64))
+func Range(min, max float64) Validator {
+	description := formatNumber(min) + ".." + formatNumber(max)
+
+	// NaN and reversed bounds are reported at the registration.
+	invalidBounds := math.IsNaN(min) || math.IsNaN(max) || min > max
+
+	return Validator{
+		Func: func(v interface{}) error {
+			if invalidBounds {
+				return fmt.Errorf("%w: invalid range %s", ErrRange, description)
+			}
+
+			n, ok := numberOf(v)
+			if !ok {
+				// NaN is a number outside of any range.
+				if isNaN(v) {
+					return fmt.Errorf("%w: must be in %s", ErrRange, description)
+				}
+
+				return ErrNotNumber
+			}
+
+			if n.Cmp(big.NewFloat(min)) < 0 || n.Cmp(big.NewFloat(max)) > 0 {
+				return fmt.Errorf("%w: must be in %s", ErrRange, description)
+			}
+
+			return nil
+		},
+		Description: description,
+		check: func(v interface{}) error {
+			if invalidBounds {
+				return ErrRange
+			}
+
+			if _, ok := numberOf(v); !ok {
+				return ErrNotNumber
+			}
+
+			return nil
+		},
+	}
+}
+
+// MinLen allows only strings with at least n characters.
+func MinLen(n int) Validator {
+	return Validator{
+		Func: func(v interface{}) error {
+			if utf8.RuneCountInString(fmt.Sprint(v)) < n {
+				return fmt.Errorf("%w: must have at least %d characters", ErrTooShort, n)
+			}
+
+			return nil
+		},
+		Description: fmt.Sprintf("min length: %d", n),
+	}
+}
+
+// Match allows only strings matching the regular expression.
+//
+//   _ = cli.String(register, "name", cli.Match(regexp.MustCompile(`^[a-z]+$`)))
+func Match(re *regexp.Regexp) Validator {
+	return Validator{
+		Func: func(v interface{}) error {
+			if !re.MatchString(fmt.Sprint(v)) {
+				return fmt.Errorf("%w %s", ErrMismatch, re)
+			}
+
+			return nil
+		},
+		Description: "match: " + re.String(),
+	}
+}
+
+// NonEmpty disallows empty strings.
+var NonEmpty = Validator{
+	Func: func(v interface{}) error {
+		if fmt.Sprint(v) == "" {
+			return ErrEmpty
+		}
+
+		return nil
+	},
+	Description: "non-empty",
+}
+
+// checkValidators checks that validators support the value. Elements are
+// checked if the value holds many values.
+func checkValidators(value Value, validators []Validator) error {
+	if len(validators) == 0 || value == nil {
+		return nil
+	}
+
+	v := valueOf(value)
+	if isSliceValue(value) {
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+			v = reflect.Zero(rv.Type().Elem()).Interface()
+		}
+	}
+
+	for _, validator := range validators {
+		if validator.check == nil {
+			continue
+		}
+
+		if err := validator.check(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateValue runs validators for the value or for every element if the
+// value holds many values.
+func validateValue(value Value, validators []Validator) error {
+	if len(validators) == 0 {
+		return nil
+	}
+
+	v := valueOf(value)
+
+	values := []interface{}{v}
+	if rv := reflect.ValueOf(v); isSliceValue(value) && rv.Kind() == reflect.Slice {
+		values = make([]interface{}, rv.Len())
+		for i := range values {
+			values[i] = rv.Index(i).Interface()
+		}
+	}
+
+	for _, validator := range validators {
+		if validator.Func == nil {
+			continue
+		}
+
+		for _, v := range values {
+			if err := validator.Func(v); err != nil {
+				var typ string
+				if t, ok := value.(Typer); ok {
+					typ = t.Type()
+				}
+
+				return &ParseValueError{
+					Type: typ,
+					Err:  err,
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func isSliceValue(value Value) bool {
+	sv, ok := value.(sliceValue)
+	return ok && sv.IsSliceValue()
+}
+
+// valueOf returns the value of the Getter or the string value.
+func valueOf(value Value) interface{} {
+	if g, ok := value.(Getter); ok {
+		return g.Get()
+	}
+
+	return value.String()
+}
+
+// numberOf returns the exact number: integers are not rounded to float64.
+func numberOf(v interface{}) (*big.Float, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(rv.Int()), true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Float).SetUint64(rv.Uint()), true
+
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) {
+			return nil, false
+		}
+
+		return big.NewFloat(f), true
+
+	default:
+		return nil, false
+	}
+}
+
+func isNaN(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return math.IsNaN(rv.Float())
+
+	default:
+		return false
+	}
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// validatorsNote returns descriptions of validators for the help.
+func validatorsNote(validators []Validator) []string {
+	var notes []string
+	for _, v := range validators {
+		if v.Description != "" {
+			notes = append(notes, v.Description)
+		}
+	}
+
+	return notes
+}
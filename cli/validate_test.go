@@ -0,0 +1,327 @@
+package cli
+
+import (
+	"errors"
+	"math"
+	"net"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestParser_Parse_validators(t *testing.T) {
+	errOdd := errors.New("odd number")
+
+	tt := []struct {
+		name     string
+		args     []string
+		wantJobs uint8
+		wantName string
+		wantTags []string
+		wantErr  error
+	}{
+		{
+			name:     "valid",
+			args:     []string{"--jobs", "64", "--port", "8080", "app", "go", "web"},
+			wantJobs: 64,
+			wantName: "app",
+			wantTags: []string{"go", "web"},
+		},
+		{
+			name:    "out of range",
+			args:    []string{"--jobs", "0", "app"},
+			wantErr: &FlagError{Long: "jobs", Err: &ParseValueError{Type: "uint8", Err: ErrRange}},
+		},
+		{
+			name:    "custom validator",
+			args:    []string{"--port", "8081", "app"},
+			wantErr: &FlagError{Long: "port", Err: &ParseValueError{Type: "int", Err: errOdd}},
+		},
+		{
+			name:    "mismatch",
+			args:    []string{"App"},
+			wantErr: &ArgError{Name: "name", Err: &ParseValueError{Type: "string", Err: ErrMismatch}},
+		},
+		{
+			name:    "too short",
+			args:    []string{"a"},
+			wantErr: &ArgError{Name: "name", Err: &ParseValueError{Type: "string", Err: ErrTooShort}},
+		},
+		{
+			name:    "empty flag",
+			args:    []string{"--label=", "app"},
+			wantErr: &FlagError{Long: "label", Err: &ParseValueError{Type: "string", Err: ErrEmpty}},
+		},
+		{
+			name:    "short rest",
+			args:    []string{"app", "go", "w"},
+			wantErr: &ArgError{Name: "tags", Index: 2, Err: &ParseValueError{Type: "[]string", Err: ErrTooShort}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			jobs := Uint8(&register, "jobs", Range(1, 64))
+			_ = Int(&register, "port", Validate(func(v interface{}) error {
+				if v.(int)%2 != 0 {
+					return errOdd
+				}
+
+				return nil
+			}))
+			name := StringArg(&register, "name", MinLen(2), Match(regexp.MustCompile(`^[a-z]+$`)))
+			_ = String(&register, "label", NonEmpty)
+			tags := RestStrings(&register, "tags", MinLen(2))
+
+			err := parser.Parse(nil, &register, tc.args)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("Parse(): got error = %q, want error = %q", err, tc.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Parse(): failed to parse args: %s", err)
+			}
+
+			if *jobs != tc.wantJobs {
+				t.Errorf("Parse(): jobs: got = %v, want = %v", *jobs, tc.wantJobs)
+			}
+
+			if *name != tc.wantName {
+				t.Errorf("Parse(): name: got = %q, want = %q", *name, tc.wantName)
+			}
+
+			if !reflect.DeepEqual(*tags, tc.wantTags) {
+				t.Errorf("Parse(): tags: got = %#v, want = %#v", *tags, tc.wantTags)
+			}
+		})
+	}
+}
+
+func TestParser_Parse_range_large_integers(t *testing.T) {
+	tt := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{
+			name: "max",
+			args: []string{"--id", "9007199254740992"},
+		},
+		{
+			name:    "above max",
+			args:    []string{"--id", "9007199254740993"},
+			wantErr: &FlagError{Long: "id", Err: &ParseValueError{Type: "int64", Err: ErrRange}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			_ = Int64(&register, "id", Range(0, 1<<53))
+
+			err := parser.Parse(nil, &register, tc.args)
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Parse(): failed to parse args: %s", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Parse(): got error = %q, want error = %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestParser_Parse_range_nan(t *testing.T) {
+	var (
+		register DefaultRegister
+		parser   DefaultParser
+	)
+
+	_ = Float64(&register, "ratio", Range(0, 1))
+
+	want := &FlagError{Long: "ratio", Err: &ParseValueError{Type: "float64", Err: ErrRange}}
+	if err := parser.Parse(nil, &register, []string{"--ratio", "NaN"}); !errors.Is(err, want) {
+		t.Fatalf("Parse(): got error = %q, want error = %q", err, want)
+	}
+}
+
+func TestParser_Parse_ip_validators(t *testing.T) {
+	errPublic := errors.New("public address")
+
+	_, subnet, _ := net.ParseCIDR("10.0.0.0/8")
+	private := Validate(func(v interface{}) error {
+		if ip, ok := v.(net.IP); !ok || !subnet.Contains(ip) {
+			return errPublic
+		}
+
+		return nil
+	})
+
+	tt := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{
+			name: "valid",
+			args: []string{"--addr", "10.0.0.1", "--peer", "10.0.0.2", "--peer", "10.1.0.1"},
+		},
+		{
+			name:    "invalid",
+			args:    []string{"--addr", "8.8.8.8"},
+			wantErr: &FlagError{Long: "addr", Err: &ParseValueError{Type: "net.IP", Err: errPublic}},
+		},
+		{
+			name:    "invalid element",
+			args:    []string{"--peer", "10.0.0.1", "--peer", "8.8.8.8"},
+			wantErr: &FlagError{Long: "peer", Err: &ParseValueError{Type: "[]net.IP", Err: errPublic}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				register DefaultRegister
+				parser   DefaultParser
+			)
+
+			_ = IP(&register, "addr", private)
+			_ = IPs(&register, "peer", private)
+
+			err := parser.Parse(nil, &register, tc.args)
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Parse(): failed to parse args: %s", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Parse(): got error = %q, want error = %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegister_range_not_number(t *testing.T) {
+	tt := []struct {
+		name     string
+		register func(r Register)
+		want     error
+	}{
+		{
+			name:     "flag",
+			register: func(r Register) { _ = String(r, "name", Range(1, 2)) },
+			want:     &FlagError{Long: "name", Err: ErrNotNumber},
+		},
+		{
+			name:     "arg",
+			register: func(r Register) { _ = StringArg(r, "name", Range(1, 2)) },
+			want:     &ArgError{Name: "name", Err: ErrNotNumber},
+		},
+		{
+			name:     "rest",
+			register: func(r Register) { _ = RestStrings(r, "names", Range(1, 2)) },
+			want:     &RestArgsError{Name: "names", Err: ErrNotNumber},
+		},
+		{
+			name:     "nan bounds",
+			register: func(r Register) { _ = Float64(r, "ratio", Range(math.NaN(), 1)) },
+			want:     &FlagError{Long: "ratio", Err: ErrRange},
+		},
+		{
+			name:     "reversed bounds",
+			register: func(r Register) { _ = Int(r, "jobs", Range(64, 1)) },
+			want:     &FlagError{Long: "jobs", Err: ErrRange},
+		},
+		{
+			name:     "numbers",
+			register: func(r Register) { _ = RestInts(r, "numbers", Range(1, 2)) },
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var register DefaultRegister
+
+			tc.register(&register)
+
+			if err := register.Err(); !errors.Is(err, tc.want) {
+				t.Errorf("Err(): got error = %q, want error = %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestApp_Run_validators_env(t *testing.T) {
+	app := App{
+		Name: "test",
+		Args: []string{},
+		LookupEnv: func(key string) (string, bool) {
+			return "100", key == "TEST_JOBS"
+		},
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Int(cmd, "jobs", WithEnv("TEST_JOBS"), Range(1, 64))
+
+			return func(cmd *Command) error { return nil }
+		}),
+	}
+
+	err := app.Run()
+
+	want := &FlagError{Long: "jobs", Env: "TEST_JOBS", Err: &ParseValueError{Type: "int", Err: ErrRange}}
+	if !errors.Is(err, want) {
+		t.Fatalf("Run(): got error = %q, want error = %q", err, want)
+	}
+
+	const wantMsg = "cli: flag error: 'jobs': parse int error: value out of range: must be in 1..64"
+	if err.Error() != wantMsg {
+		t.Errorf("Run(): got error = %q, want error = %q", err, wantMsg)
+	}
+}
+
+func TestDefaultHelper_Help_validators(t *testing.T) {
+	app := App{
+		Name: "validators",
+		Action: ActionFunc(func(cmd *Command) ActionRunner {
+			_ = Uint8(cmd, "jobs", Range(1, 64), Usage("Number of jobs"))
+			_ = StringArg(cmd, "name", NonEmpty)
+
+			return func(cmd *Command) error { panic("not implemented") }
+		}),
+	}
+
+	cmd, err := app.Command("validators")
+	if err != nil {
+		t.Fatalf("Command(): failed to get command: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := (DefaultHelper{}).Help(cmd, &buf); err != nil {
+		t.Fatalf("Help(): failed to write help: %s", err)
+	}
+
+	assertContains(t, buf.String(),
+		"Number of jobs (1..64)\n",
+		"(non-empty)\n",
+	)
+}
@@ -3,6 +3,8 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"strconv"
 	"time"
 )
@@ -332,6 +334,81 @@ func (d *timeDurationValue) Set(s string) error {
 	return err
 }
 
+// net.IP
+
+func (i *netIPValue) Set(s string) error {
+	v := net.ParseIP(s)
+	if v == nil {
+		return &ParseValueError{
+			Type: "net.IP",
+			Err:  ErrSyntax,
+		}
+	}
+
+	*i = netIPValue(v)
+	return nil
+}
+
+func ipString(ip net.IP) string {
+	if len(ip) == 0 {
+		return ""
+	}
+
+	return ip.String()
+}
+
+// net.IPNet
+
+func (n *netIPNetValue) Set(s string) error {
+	_, v, err := net.ParseCIDR(s)
+	if err != nil {
+		return &ParseValueError{
+			Type: "net.IPNet",
+			Err:  ErrSyntax,
+		}
+	}
+
+	*n = netIPNetValue(*v)
+	return nil
+}
+
+func ipNetString(n *net.IPNet) string {
+	if len(n.IP) == 0 {
+		return ""
+	}
+
+	return n.String()
+}
+
+// url.URL
+
+func (u *urlURLValue) Set(s string) error {
+	v, err := url.Parse(s)
+	if err != nil {
+		return &ParseValueError{
+			Type: "url.URL",
+			Err:  ErrSyntax,
+		}
+	}
+
+	// Only absolute URLs are accepted.
+	if v.Scheme == "" {
+		return &ParseValueError{
+			Type: "url.URL",
+			Err:  fmt.Errorf("%w: missing scheme", ErrScheme),
+		}
+	}
+
+	*u = urlURLValue(*v)
+	return nil
+}
+
+// sliceValue holds many values. Validators check every value.
+type sliceValue interface {
+	Value
+	IsSliceValue() bool
+}
+
 //go:generate python ./generate_value.py
 
 //go:generate python ./generate_values.py
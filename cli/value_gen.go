@@ -3,6 +3,8 @@
 package cli
 
 import (
+	"net"
+	"net/url"
 	"strconv"
 	"time"
 )
@@ -351,3 +353,72 @@ func (v *timeDurationValue) Empty() bool { return *v == 0 }
 func (v *timeDurationValue) String() string { return (*time.Duration)(v).String() }
 
 func (*timeDurationValue) Type() string { return "time.Duration" }
+
+// net.IP
+
+var (
+	_ Value   = (*netIPValue)(nil)
+	_ Getter  = (*netIPValue)(nil)
+	_ Emptier = (*netIPValue)(nil)
+	_ Typer   = (*netIPValue)(nil)
+)
+
+type netIPValue net.IP
+
+func newIPValue(p *net.IP) *netIPValue {
+	return (*netIPValue)(p)
+}
+
+func (v *netIPValue) Get() interface{} { return net.IP(*v) }
+
+func (v *netIPValue) Empty() bool { return len(*v) == 0 }
+
+func (v *netIPValue) String() string { return ipString(net.IP(*v)) }
+
+func (*netIPValue) Type() string { return "net.IP" }
+
+// net.IPNet
+
+var (
+	_ Value   = (*netIPNetValue)(nil)
+	_ Getter  = (*netIPNetValue)(nil)
+	_ Emptier = (*netIPNetValue)(nil)
+	_ Typer   = (*netIPNetValue)(nil)
+)
+
+type netIPNetValue net.IPNet
+
+func newIPNetValue(p *net.IPNet) *netIPNetValue {
+	return (*netIPNetValue)(p)
+}
+
+func (v *netIPNetValue) Get() interface{} { return net.IPNet(*v) }
+
+func (v *netIPNetValue) Empty() bool { return len(v.IP) == 0 }
+
+func (v *netIPNetValue) String() string { return ipNetString((*net.IPNet)(v)) }
+
+func (*netIPNetValue) Type() string { return "net.IPNet" }
+
+// url.URL
+
+var (
+	_ Value   = (*urlURLValue)(nil)
+	_ Getter  = (*urlURLValue)(nil)
+	_ Emptier = (*urlURLValue)(nil)
+	_ Typer   = (*urlURLValue)(nil)
+)
+
+type urlURLValue url.URL
+
+func newURLValue(p *url.URL) *urlURLValue {
+	return (*urlURLValue)(p)
+}
+
+func (v *urlURLValue) Get() interface{} { return url.URL(*v) }
+
+func (v *urlURLValue) Empty() bool { return (*url.URL)(v).String() == "" }
+
+func (v *urlURLValue) String() string { return (*url.URL)(v).String() }
+
+func (*urlURLValue) Type() string { return "url.URL" }
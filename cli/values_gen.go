@@ -3,6 +3,8 @@
 package cli
 
 import (
+	"net"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -10,10 +12,11 @@ import (
 // []bool
 
 var (
-	_ Value   = (*boolValues)(nil)
-	_ Getter  = (*boolValues)(nil)
-	_ Emptier = (*boolValues)(nil)
-	_ Typer   = (*boolValues)(nil)
+	_ Value      = (*boolValues)(nil)
+	_ Getter     = (*boolValues)(nil)
+	_ Emptier    = (*boolValues)(nil)
+	_ Typer      = (*boolValues)(nil)
+	_ sliceValue = (*boolValues)(nil)
 )
 
 type boolValues []bool
@@ -66,13 +69,16 @@ func (v *boolValues) Get() interface{} { return []bool(*v) }
 
 func (*boolValues) Type() string { return "[]bool" }
 
+func (*boolValues) IsSliceValue() bool { return true }
+
 // []uint8
 
 var (
-	_ Value   = (*uint8Values)(nil)
-	_ Getter  = (*uint8Values)(nil)
-	_ Emptier = (*uint8Values)(nil)
-	_ Typer   = (*uint8Values)(nil)
+	_ Value      = (*uint8Values)(nil)
+	_ Getter     = (*uint8Values)(nil)
+	_ Emptier    = (*uint8Values)(nil)
+	_ Typer      = (*uint8Values)(nil)
+	_ sliceValue = (*uint8Values)(nil)
 )
 
 type uint8Values []uint8
@@ -125,13 +131,16 @@ func (v *uint8Values) Get() interface{} { return []uint8(*v) }
 
 func (*uint8Values) Type() string { return "[]uint8" }
 
+func (*uint8Values) IsSliceValue() bool { return true }
+
 // []uint16
 
 var (
-	_ Value   = (*uint16Values)(nil)
-	_ Getter  = (*uint16Values)(nil)
-	_ Emptier = (*uint16Values)(nil)
-	_ Typer   = (*uint16Values)(nil)
+	_ Value      = (*uint16Values)(nil)
+	_ Getter     = (*uint16Values)(nil)
+	_ Emptier    = (*uint16Values)(nil)
+	_ Typer      = (*uint16Values)(nil)
+	_ sliceValue = (*uint16Values)(nil)
 )
 
 type uint16Values []uint16
@@ -184,13 +193,16 @@ func (v *uint16Values) Get() interface{} { return []uint16(*v) }
 
 func (*uint16Values) Type() string { return "[]uint16" }
 
+func (*uint16Values) IsSliceValue() bool { return true }
+
 // []uint32
 
 var (
-	_ Value   = (*uint32Values)(nil)
-	_ Getter  = (*uint32Values)(nil)
-	_ Emptier = (*uint32Values)(nil)
-	_ Typer   = (*uint32Values)(nil)
+	_ Value      = (*uint32Values)(nil)
+	_ Getter     = (*uint32Values)(nil)
+	_ Emptier    = (*uint32Values)(nil)
+	_ Typer      = (*uint32Values)(nil)
+	_ sliceValue = (*uint32Values)(nil)
 )
 
 type uint32Values []uint32
@@ -243,13 +255,16 @@ func (v *uint32Values) Get() interface{} { return []uint32(*v) }
 
 func (*uint32Values) Type() string { return "[]uint32" }
 
+func (*uint32Values) IsSliceValue() bool { return true }
+
 // []uint64
 
 var (
-	_ Value   = (*uint64Values)(nil)
-	_ Getter  = (*uint64Values)(nil)
-	_ Emptier = (*uint64Values)(nil)
-	_ Typer   = (*uint64Values)(nil)
+	_ Value      = (*uint64Values)(nil)
+	_ Getter     = (*uint64Values)(nil)
+	_ Emptier    = (*uint64Values)(nil)
+	_ Typer      = (*uint64Values)(nil)
+	_ sliceValue = (*uint64Values)(nil)
 )
 
 type uint64Values []uint64
@@ -302,13 +317,16 @@ func (v *uint64Values) Get() interface{} { return []uint64(*v) }
 
 func (*uint64Values) Type() string { return "[]uint64" }
 
+func (*uint64Values) IsSliceValue() bool { return true }
+
 // []int8
 
 var (
-	_ Value   = (*int8Values)(nil)
-	_ Getter  = (*int8Values)(nil)
-	_ Emptier = (*int8Values)(nil)
-	_ Typer   = (*int8Values)(nil)
+	_ Value      = (*int8Values)(nil)
+	_ Getter     = (*int8Values)(nil)
+	_ Emptier    = (*int8Values)(nil)
+	_ Typer      = (*int8Values)(nil)
+	_ sliceValue = (*int8Values)(nil)
 )
 
 type int8Values []int8
@@ -361,13 +379,16 @@ func (v *int8Values) Get() interface{} { return []int8(*v) }
 
 func (*int8Values) Type() string { return "[]int8" }
 
+func (*int8Values) IsSliceValue() bool { return true }
+
 // []int16
 
 var (
-	_ Value   = (*int16Values)(nil)
-	_ Getter  = (*int16Values)(nil)
-	_ Emptier = (*int16Values)(nil)
-	_ Typer   = (*int16Values)(nil)
+	_ Value      = (*int16Values)(nil)
+	_ Getter     = (*int16Values)(nil)
+	_ Emptier    = (*int16Values)(nil)
+	_ Typer      = (*int16Values)(nil)
+	_ sliceValue = (*int16Values)(nil)
 )
 
 type int16Values []int16
@@ -420,13 +441,16 @@ func (v *int16Values) Get() interface{} { return []int16(*v) }
 
 func (*int16Values) Type() string { return "[]int16" }
 
+func (*int16Values) IsSliceValue() bool { return true }
+
 // []int32
 
 var (
-	_ Value   = (*int32Values)(nil)
-	_ Getter  = (*int32Values)(nil)
-	_ Emptier = (*int32Values)(nil)
-	_ Typer   = (*int32Values)(nil)
+	_ Value      = (*int32Values)(nil)
+	_ Getter     = (*int32Values)(nil)
+	_ Emptier    = (*int32Values)(nil)
+	_ Typer      = (*int32Values)(nil)
+	_ sliceValue = (*int32Values)(nil)
 )
 
 type int32Values []int32
@@ -479,13 +503,16 @@ func (v *int32Values) Get() interface{} { return []int32(*v) }
 
 func (*int32Values) Type() string { return "[]int32" }
 
+func (*int32Values) IsSliceValue() bool { return true }
+
 // []int64
 
 var (
-	_ Value   = (*int64Values)(nil)
-	_ Getter  = (*int64Values)(nil)
-	_ Emptier = (*int64Values)(nil)
-	_ Typer   = (*int64Values)(nil)
+	_ Value      = (*int64Values)(nil)
+	_ Getter     = (*int64Values)(nil)
+	_ Emptier    = (*int64Values)(nil)
+	_ Typer      = (*int64Values)(nil)
+	_ sliceValue = (*int64Values)(nil)
 )
 
 type int64Values []int64
@@ -538,13 +565,16 @@ func (v *int64Values) Get() interface{} { return []int64(*v) }
 
 func (*int64Values) Type() string { return "[]int64" }
 
+func (*int64Values) IsSliceValue() bool { return true }
+
 // []float32
 
 var (
-	_ Value   = (*float32Values)(nil)
-	_ Getter  = (*float32Values)(nil)
-	_ Emptier = (*float32Values)(nil)
-	_ Typer   = (*float32Values)(nil)
+	_ Value      = (*float32Values)(nil)
+	_ Getter     = (*float32Values)(nil)
+	_ Emptier    = (*float32Values)(nil)
+	_ Typer      = (*float32Values)(nil)
+	_ sliceValue = (*float32Values)(nil)
 )
 
 type float32Values []float32
@@ -597,13 +627,16 @@ func (v *float32Values) Get() interface{} { return []float32(*v) }
 
 func (*float32Values) Type() string { return "[]float32" }
 
+func (*float32Values) IsSliceValue() bool { return true }
+
 // []float64
 
 var (
-	_ Value   = (*float64Values)(nil)
-	_ Getter  = (*float64Values)(nil)
-	_ Emptier = (*float64Values)(nil)
-	_ Typer   = (*float64Values)(nil)
+	_ Value      = (*float64Values)(nil)
+	_ Getter     = (*float64Values)(nil)
+	_ Emptier    = (*float64Values)(nil)
+	_ Typer      = (*float64Values)(nil)
+	_ sliceValue = (*float64Values)(nil)
 )
 
 type float64Values []float64
@@ -656,13 +689,16 @@ func (v *float64Values) Get() interface{} { return []float64(*v) }
 
 func (*float64Values) Type() string { return "[]float64" }
 
+func (*float64Values) IsSliceValue() bool { return true }
+
 // []string
 
 var (
-	_ Value   = (*stringValues)(nil)
-	_ Getter  = (*stringValues)(nil)
-	_ Emptier = (*stringValues)(nil)
-	_ Typer   = (*stringValues)(nil)
+	_ Value      = (*stringValues)(nil)
+	_ Getter     = (*stringValues)(nil)
+	_ Emptier    = (*stringValues)(nil)
+	_ Typer      = (*stringValues)(nil)
+	_ sliceValue = (*stringValues)(nil)
 )
 
 type stringValues []string
@@ -715,13 +751,16 @@ func (v *stringValues) Get() interface{} { return []string(*v) }
 
 func (*stringValues) Type() string { return "[]string" }
 
+func (*stringValues) IsSliceValue() bool { return true }
+
 // []int
 
 var (
-	_ Value   = (*intValues)(nil)
-	_ Getter  = (*intValues)(nil)
-	_ Emptier = (*intValues)(nil)
-	_ Typer   = (*intValues)(nil)
+	_ Value      = (*intValues)(nil)
+	_ Getter     = (*intValues)(nil)
+	_ Emptier    = (*intValues)(nil)
+	_ Typer      = (*intValues)(nil)
+	_ sliceValue = (*intValues)(nil)
 )
 
 type intValues []int
@@ -774,13 +813,16 @@ func (v *intValues) Get() interface{} { return []int(*v) }
 
 func (*intValues) Type() string { return "[]int" }
 
+func (*intValues) IsSliceValue() bool { return true }
+
 // []uint
 
 var (
-	_ Value   = (*uintValues)(nil)
-	_ Getter  = (*uintValues)(nil)
-	_ Emptier = (*uintValues)(nil)
-	_ Typer   = (*uintValues)(nil)
+	_ Value      = (*uintValues)(nil)
+	_ Getter     = (*uintValues)(nil)
+	_ Emptier    = (*uintValues)(nil)
+	_ Typer      = (*uintValues)(nil)
+	_ sliceValue = (*uintValues)(nil)
 )
 
 type uintValues []uint
@@ -833,13 +875,16 @@ func (v *uintValues) Get() interface{} { return []uint(*v) }
 
 func (*uintValues) Type() string { return "[]uint" }
 
+func (*uintValues) IsSliceValue() bool { return true }
+
 // []time.Duration
 
 var (
-	_ Value   = (*timeDurationValues)(nil)
-	_ Getter  = (*timeDurationValues)(nil)
-	_ Emptier = (*timeDurationValues)(nil)
-	_ Typer   = (*timeDurationValues)(nil)
+	_ Value      = (*timeDurationValues)(nil)
+	_ Getter     = (*timeDurationValues)(nil)
+	_ Emptier    = (*timeDurationValues)(nil)
+	_ Typer      = (*timeDurationValues)(nil)
+	_ sliceValue = (*timeDurationValues)(nil)
 )
 
 type timeDurationValues []time.Duration
@@ -891,3 +936,191 @@ func (v *timeDurationValues) Empty() bool { return len(*v) == 0 }
 func (v *timeDurationValues) Get() interface{} { return []time.Duration(*v) }
 
 func (*timeDurationValues) Type() string { return "[]time.Duration" }
+
+func (*timeDurationValues) IsSliceValue() bool { return true }
+
+// []net.IP
+
+var (
+	_ Value      = (*netIPValues)(nil)
+	_ Getter     = (*netIPValues)(nil)
+	_ Emptier    = (*netIPValues)(nil)
+	_ Typer      = (*netIPValues)(nil)
+	_ sliceValue = (*netIPValues)(nil)
+)
+
+type netIPValues []net.IP
+
+func newIPValues(p *[]net.IP) *netIPValues {
+	return (*netIPValues)(p)
+}
+
+func (vs *netIPValues) Set(val string) error {
+	rest := val
+	for rest != "" {
+		idx := strings.IndexByte(rest, ',')
+		if idx != -1 {
+			val = rest[:idx]
+			rest = rest[idx+1:]
+		} else {
+			val = rest
+			rest = ""
+		}
+
+		var def net.IP
+		*vs = append(*vs, def)
+		if err := (*netIPValue)(&(*vs)[len(*vs)-1]).Set(val); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (vs *netIPValues) String() string {
+	if len(*vs) == 0 {
+		return ""
+	}
+
+	var buf strings.Builder
+	_, _ = buf.WriteString((*netIPValue)(&(*vs)[0]).String())
+
+	for i := 1; i < len(*vs); i++ {
+		_ = buf.WriteByte(',')
+		_, _ = buf.WriteString((*netIPValue)(&(*vs)[i]).String())
+	}
+
+	return buf.String()
+}
+
+func (v *netIPValues) Empty() bool { return len(*v) == 0 }
+
+func (v *netIPValues) Get() interface{} { return []net.IP(*v) }
+
+func (*netIPValues) Type() string { return "[]net.IP" }
+
+func (*netIPValues) IsSliceValue() bool { return true }
+
+// []net.IPNet
+
+var (
+	_ Value      = (*netIPNetValues)(nil)
+	_ Getter     = (*netIPNetValues)(nil)
+	_ Emptier    = (*netIPNetValues)(nil)
+	_ Typer      = (*netIPNetValues)(nil)
+	_ sliceValue = (*netIPNetValues)(nil)
+)
+
+type netIPNetValues []net.IPNet
+
+func newIPNetValues(p *[]net.IPNet) *netIPNetValues {
+	return (*netIPNetValues)(p)
+}
+
+func (vs *netIPNetValues) Set(val string) error {
+	rest := val
+	for rest != "" {
+		idx := strings.IndexByte(rest, ',')
+		if idx != -1 {
+			val = rest[:idx]
+			rest = rest[idx+1:]
+		} else {
+			val = rest
+			rest = ""
+		}
+
+		var def net.IPNet
+		*vs = append(*vs, def)
+		if err := (*netIPNetValue)(&(*vs)[len(*vs)-1]).Set(val); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (vs *netIPNetValues) String() string {
+	if len(*vs) == 0 {
+		return ""
+	}
+
+	var buf strings.Builder
+	_, _ = buf.WriteString((*netIPNetValue)(&(*vs)[0]).String())
+
+	for i := 1; i < len(*vs); i++ {
+		_ = buf.WriteByte(',')
+		_, _ = buf.WriteString((*netIPNetValue)(&(*vs)[i]).String())
+	}
+
+	return buf.String()
+}
+
+func (v *netIPNetValues) Empty() bool { return len(*v) == 0 }
+
+func (v *netIPNetValues) Get() interface{} { return []net.IPNet(*v) }
+
+func (*netIPNetValues) Type() string { return "[]net.IPNet" }
+
+func (*netIPNetValues) IsSliceValue() bool { return true }
+
+// []url.URL
+
+var (
+	_ Value      = (*urlURLValues)(nil)
+	_ Getter     = (*urlURLValues)(nil)
+	_ Emptier    = (*urlURLValues)(nil)
+	_ Typer      = (*urlURLValues)(nil)
+	_ sliceValue = (*urlURLValues)(nil)
+)
+
+type urlURLValues []url.URL
+
+func newURLValues(p *[]url.URL) *urlURLValues {
+	return (*urlURLValues)(p)
+}
+
+func (vs *urlURLValues) Set(val string) error {
+	rest := val
+	for rest != "" {
+		idx := strings.IndexByte(rest, ',')
+		if idx != -1 {
+			val = rest[:idx]
+			rest = rest[idx+1:]
+		} else {
+			val = rest
+			rest = ""
+		}
+
+		var def url.URL
+		*vs = append(*vs, def)
+		if err := (*urlURLValue)(&(*vs)[len(*vs)-1]).Set(val); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (vs *urlURLValues) String() string {
+	if len(*vs) == 0 {
+		return ""
+	}
+
+	var buf strings.Builder
+	_, _ = buf.WriteString((*urlURLValue)(&(*vs)[0]).String())
+
+	for i := 1; i < len(*vs); i++ {
+		_ = buf.WriteByte(',')
+		_, _ = buf.WriteString((*urlURLValue)(&(*vs)[i]).String())
+	}
+
+	return buf.String()
+}
+
+func (v *urlURLValues) Empty() bool { return len(*v) == 0 }
+
+func (v *urlURLValues) Get() interface{} { return []url.URL(*v) }
+
+func (*urlURLValues) Type() string { return "[]url.URL" }
+
+func (*urlURLValues) IsSliceValue() bool { return true }